@@ -0,0 +1,137 @@
+package golden
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AdaptiveDiffOptions configures EqualAdaptive. The strategies are used instead of the built-in ones when set.
+type AdaptiveDiffOptions struct {
+	// MaxLines is the number of diff lines after which the diff is truncated, defaults to 100.
+	MaxLines int
+	// LongLine is the average line length above which the content is considered long lines, defaults to 200.
+	LongLine int
+
+	// Binary compares content which isn't valid UTF-8 or contains NUL bytes, defaults to EqualBinary.
+	Binary func(t T, expected, actual string, msgAndArgs ...interface{}) bool
+	// JSON compares JSON objects and arrays, defaults to unified diff of the PrettyJSON formatted content.
+	JSON func(t T, expected, actual string, msgAndArgs ...interface{}) bool
+	// LongLines compares content with long lines, e.g. minified output, defaults to showing the first difference in context.
+	LongLines func(t T, expected, actual string, msgAndArgs ...interface{}) bool
+	// Text compares the rest, defaults to unified diff truncated to MaxLines lines.
+	Text func(t T, expected, actual string, msgAndArgs ...interface{}) bool
+}
+
+// EqualAdaptive returns Equal function which chooses the diff presentation based on the content:
+// binary content is reported by size and the offset of the first difference, JSON as diff of the formatted JSON,
+// long lines as the first difference in context and the rest as unified diff truncated to MaxLines lines.
+// It can be enabled for all the tests with:
+//
+//	golden.DefaultHandler.Equal = golden.EqualAdaptive(golden.AdaptiveDiffOptions{})
+func EqualAdaptive(opts AdaptiveDiffOptions) func(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+	if opts.MaxLines <= 0 {
+		opts.MaxLines = 100
+	}
+	if opts.LongLine <= 0 {
+		opts.LongLine = 200
+	}
+	if opts.Binary == nil {
+		opts.Binary = EqualBinary
+	}
+	if opts.JSON == nil {
+		opts.JSON = equalJSONDiff(opts.MaxLines)
+	}
+	if opts.LongLines == nil {
+		opts.LongLines = EqualWithContext(40)
+	}
+	if opts.Text == nil {
+		opts.Text = EqualWithTruncatedDiff(opts.MaxLines)
+	}
+
+	return func(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+		t.Helper()
+		if expected == actual {
+			return true
+		}
+
+		switch e, a := []byte(expected), []byte(actual); {
+		case IsBinary(e) || IsBinary(a):
+			return opts.Binary(t, expected, actual, msgAndArgs...)
+		case IsJSON(e) && IsJSON(a):
+			return opts.JSON(t, expected, actual, msgAndArgs...)
+		case averageLineLength(expected) > opts.LongLine || averageLineLength(actual) > opts.LongLine:
+			return opts.LongLines(t, expected, actual, msgAndArgs...)
+		default:
+			return opts.Text(t, expected, actual, msgAndArgs...)
+		}
+	}
+}
+
+func averageLineLength(s string) int {
+	return len(s) / (strings.Count(strings.TrimSuffix(s, "\n"), "\n") + 1)
+}
+
+// equalJSONDiff reports the diff of the formatted JSON, so changes in minified JSON are shown per value.
+func equalJSONDiff(maxLines int) func(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+	return func(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+		t.Helper()
+		if expected == actual {
+			return true
+		}
+
+		diff, hunks, lines := TruncateDiff(UnifiedDiff("expected", "actual", PrettyJSON(t, expected), PrettyJSON(t, actual)), maxLines)
+		msg := "Not equal:\n" + diff
+		if diff == "" {
+			msg = "Not equal: JSON differs only in formatting"
+		}
+		if lines > 0 {
+			msg += "\n" + truncationNote(hunks, lines)
+		}
+		if len(msgAndArgs) > 0 {
+			msg += "\n" + messageFromMsgAndArgs(msgAndArgs...)
+		}
+		t.Errorf("%s", msg)
+		return false
+	}
+}
+
+// EqualWithContext returns Equal function which reports the position of the first difference
+// and the given number of bytes around it from both contents. It suits content with long lines
+// where line based diff would print the whole content.
+func EqualWithContext(context int) func(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+	return func(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+		t.Helper()
+		if expected == actual {
+			return true
+		}
+
+		offset := 0
+		for offset < len(expected) && offset < len(actual) && expected[offset] == actual[offset] {
+			offset++
+		}
+		line := strings.Count(expected[:offset], "\n") + 1
+		column := offset - strings.LastIndex(expected[:offset], "\n")
+
+		msg := fmt.Sprintf("Not equal: first difference at line %d, column %d (offset %d)\nexpected: %s\nactual:   %s",
+			line, column, offset, excerpt(expected, offset, context), excerpt(actual, offset, context))
+		if len(msgAndArgs) > 0 {
+			msg += "\n" + messageFromMsgAndArgs(msgAndArgs...)
+		}
+		t.Errorf("%s", msg)
+		return false
+	}
+}
+
+// excerpt returns the quoted part of s around the offset with ellipses marking the cut ends.
+func excerpt(s string, offset, context int) string {
+	start, end := max(offset-context, 0), min(offset+context, len(s))
+	out := strconv.Quote(s[start:end])
+	if start > 0 {
+		out = "..." + out
+	}
+	if end < len(s) {
+		out += "..."
+	}
+	return out
+}
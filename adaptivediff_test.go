@@ -0,0 +1,53 @@
+package golden_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqualAdaptive(t *testing.T) {
+	equal := golden.EqualAdaptive(golden.AdaptiveDiffOptions{MaxLines: 8})
+	minified := `{"a":1,"b":[1,2,3],"c":"` + strings.Repeat("x", 300) + `"}`
+
+	tests := []struct {
+		name             string
+		expected, actual string
+		contains         string
+	}{
+		{name: "binary", expected: "\x00\x01", actual: "\x00\x02", contains: "first difference at offset 1"},
+		{name: "json", expected: minified, actual: strings.Replace(minified, `"a":1`, `"a":2`, 1), contains: "-  \"a\": 1,\n+  \"a\": 2,"},
+		{name: "json formatting", expected: `{"a": 1}`, actual: `{"a":1}`, contains: "JSON differs only in formatting"},
+		{name: "long line", expected: strings.Repeat("a", 300) + "b", actual: strings.Repeat("a", 300) + "c", contains: "first difference at line 1, column 301 (offset 300)"},
+		{name: "text", expected: strings.Repeat("a\n", 20), actual: strings.Repeat("b\n", 20), contains: "lines omitted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockT{name: "TestEqualAdaptive"}
+			assert.True(t, equal(mt, tt.expected, tt.expected))
+			assert.False(t, equal(mt, tt.expected, tt.actual, "extra %s", "message"))
+			assert.Contains(t, mt.msg, tt.contains)
+			assert.Contains(t, mt.msg, "extra message")
+		})
+	}
+}
+
+func TestEqualAdaptive_Override(t *testing.T) {
+	called := false
+	equal := golden.EqualAdaptive(golden.AdaptiveDiffOptions{
+		Text: func(t golden.T, expected, actual string, msgAndArgs ...interface{}) bool {
+			called = true
+			return false
+		},
+	})
+	assert.False(t, equal(&mockT{}, "a", "b"))
+	assert.True(t, called)
+}
+
+func TestEqualWithContext(t *testing.T) {
+	mt := &mockT{}
+	assert.False(t, golden.EqualWithContext(3)(mt, "line\nabcdefgh", "line\nabcXefgh"))
+	assert.Contains(t, mt.msg, "first difference at line 2, column 4 (offset 8)\nexpected: ...\"abcdef\"...\nactual:   ...\"abcXef\"...")
+}
@@ -0,0 +1,144 @@
+package golden
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// ArchiveOptions configures the rendering of the archives in AssertArchive.
+type ArchiveOptions struct {
+	// Contents includes the content of the files after their manifest lines, binary content is rendered with HexDump.
+	// Only the manifest with the content hashes is rendered by default.
+	Contents bool
+}
+
+// AssertArchive checks the golden file content against the manifest of the tar, gzip compressed tar or zip archive
+// rendered by RenderArchive using the handler's Archive options, e.g. for testing packaging or exporter code.
+func AssertArchive(t T, r io.Reader) bool {
+	return Default().AssertArchive(t, r)
+}
+
+func (h *FileHandler) AssertArchive(t T, r io.Reader) bool {
+	t.Helper()
+	manifest, err := RenderArchive(r, h.Archive)
+	NoError(t, err, "failed to read archive")
+	if err != nil {
+		return false
+	}
+	return h.Assert(t, manifest)
+}
+
+// RenderArchive renders the entries of the archive in their archive order, one per line, e.g.
+//
+//	-rw-r--r--       12 sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03 docs/README.md
+//
+// The modification times and the owners aren't rendered, so rebuilding the archive doesn't change the manifest.
+// The format is detected from the content: zip, gzip compressed tar or plain tar.
+func RenderArchive(r io.Reader, opts ArchiveOptions) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	var entries []archiveEntry
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")) || bytes.HasPrefix(data, []byte("PK\x05\x06")):
+		entries, err = zipEntries(data)
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gz, gzErr := gzip.NewReader(bytes.NewReader(data))
+		if gzErr != nil {
+			return "", gzErr
+		}
+		entries, err = tarEntries(gz)
+	default:
+		entries, err = tarEntries(bytes.NewReader(data))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	sb := &strings.Builder{}
+	for _, e := range entries {
+		hash := "-"
+		if e.mode.IsRegular() {
+			hash = fmt.Sprintf("sha256:%x", sha256.Sum256(e.content))
+		}
+		name := e.name
+		if e.link != "" {
+			name += " -> " + e.link
+		}
+		fmt.Fprintf(sb, "%s %8d %s %s\n", e.mode, len(e.content), hash, name)
+		if opts.Contents && e.mode.IsRegular() && len(e.content) > 0 {
+			content := string(e.content)
+			if HasNonPrintable(content) {
+				content = HexDump(content)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+				sb.WriteString("    " + line + "\n")
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+type archiveEntry struct {
+	name    string
+	mode    fs.FileMode
+	link    string
+	content []byte
+}
+
+func tarEntries(r io.Reader) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, archiveEntry{name: hdr.Name, mode: hdr.FileInfo().Mode(), link: hdr.Linkname, content: content})
+	}
+}
+
+func zipEntries(data []byte) ([]archiveEntry, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]archiveEntry, 0, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		e := archiveEntry{name: f.Name, mode: f.Mode(), content: content}
+		if e.mode&fs.ModeSymlink != 0 {
+			e.link, e.content = string(content), nil
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
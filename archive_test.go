@@ -0,0 +1,85 @@
+package golden_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type archiveFile struct {
+	name, content string
+	mode          int64
+}
+
+var archiveFiles = []archiveFile{
+	{name: "bin/", mode: 0o755},
+	{name: "bin/tool", content: "\x7fELF\x02\x01\x01\x00", mode: 0o755},
+	{name: "docs/README.md", content: "# Tool\n\nUsage: tool [flags]\n", mode: 0o644},
+}
+
+func tarGz(t *testing.T, modTime time.Time) []byte {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range archiveFiles {
+		hdr := &tar.Header{Name: f.name, Mode: f.mode, Size: int64(len(f.content)), ModTime: modTime, Typeflag: tar.TypeReg}
+		if f.name[len(f.name)-1] == '/' {
+			hdr.Typeflag = tar.TypeDir
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "bin/latest", Linkname: "tool", Mode: 0o777, Typeflag: tar.TypeSymlink, ModTime: modTime}))
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestAssertArchive(t *testing.T) {
+	golden.AssertArchive(t, bytes.NewReader(tarGz(t, time.Now())))
+}
+
+func TestRenderArchive(t *testing.T) {
+	a, err := golden.RenderArchive(bytes.NewReader(tarGz(t, time.Unix(0, 0))), golden.ArchiveOptions{})
+	require.NoError(t, err)
+	b, err := golden.RenderArchive(bytes.NewReader(tarGz(t, time.Now())), golden.ArchiveOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, a, b, "timestamps aren't rendered")
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, f := range archiveFiles {
+		hdr := &zip.FileHeader{Name: f.name, Modified: time.Now()}
+		hdr.SetMode(0o644)
+		if f.name[len(f.name)-1] == '/' {
+			hdr.SetMode(0o755 | 1<<31)
+		}
+		w, err := zw.CreateHeader(hdr)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	manifest, err := golden.RenderArchive(buf, golden.ArchiveOptions{Contents: true})
+	require.NoError(t, err)
+	assert.Equal(t, "drwxr-xr-x        0 - bin/\n"+
+		"-rw-r--r--        8 sha256:e94466faac02d08efbb3109dbe52d0c13c5a53859e328d50dbeb2e6f0ee89871 bin/tool\n"+
+		"    00000000  7f 45 4c 46 02 01 01 00                           |.ELF....|\n"+
+		"    00000008\n"+
+		"-rw-r--r--       28 sha256:95fa2c51e4f1087045cc8f679da3ca79589d8b4b3b87052a5fb9eaa692c71329 docs/README.md\n"+
+		"    # Tool\n"+
+		"    \n"+
+		"    Usage: tool [flags]\n", manifest)
+
+	_, err = golden.RenderArchive(bytes.NewReader([]byte("PK\x03\x04broken")), golden.ArchiveOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package golden
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AudioOptions configures the audio fingerprints of AssertAudio.
+type AudioOptions struct {
+	// Window is the length of the windows the RMS is computed for, defaults to 100ms.
+	Window time.Duration
+	// Precision is the number of decimals the RMS values are rounded to, defaults to 2.
+	Precision int
+}
+
+// Audio is decoded PCM audio with the samples normalized to the range [-1, 1].
+type Audio struct {
+	SampleRate int
+	Channels   int
+	// Samples are interleaved, i.e. one sample per channel for each frame.
+	Samples []float64
+}
+
+// AssertAudio decodes the WAV data and checks the golden file content against its fingerprint, see AudioFingerprint.
+// Only the summary is stored, so audio generating code can be tested without committing large exact waveforms.
+func AssertAudio(t T, wav []byte) bool {
+	return Default().AssertAudio(t, wav)
+}
+
+func (h *FileHandler) AssertAudio(t T, wav []byte) bool {
+	t.Helper()
+	audio, err := DecodeWAV(wav)
+	if err != nil {
+		NoError(t, err, "failed to decode WAV")
+		return false
+	}
+	return h.Assert(t, AudioFingerprint(audio, h.Audio))
+}
+
+// AudioFingerprint renders deterministic summary of the audio: duration, sample rate, channel count and
+// the RMS of each channel per window rounded to the configured precision, one window per line.
+func AudioFingerprint(audio Audio, opts AudioOptions) string {
+	window := opts.Window
+	if window <= 0 {
+		window = 100 * time.Millisecond
+	}
+	precision := opts.Precision
+	if precision <= 0 {
+		precision = 2
+	}
+
+	channels := max(audio.Channels, 1)
+	frames := len(audio.Samples) / channels
+	duration := time.Duration(0)
+	if audio.SampleRate > 0 {
+		duration = time.Duration(frames) * time.Second / time.Duration(audio.SampleRate)
+	}
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "duration: %s\nsample rate: %d\nchannels: %d\nwindow: %s\nrms:\n", duration, audio.SampleRate, channels, window)
+
+	windowFrames := max(int(int64(audio.SampleRate)*int64(window)/int64(time.Second)), 1)
+	for start := 0; start < frames; start += windowFrames {
+		end := min(start+windowFrames, frames)
+		values := make([]string, channels)
+		for c := range channels {
+			sum := 0.0
+			for f := start; f < end; f++ {
+				s := audio.Samples[f*channels+c]
+				sum += s * s
+			}
+			values[c] = strconv.FormatFloat(math.Sqrt(sum/float64(end-start)), 'f', precision, 64)
+		}
+		fmt.Fprintf(sb, "  %s\n", strings.Join(values, " "))
+	}
+	return sb.String()
+}
+
+// DecodeWAV decodes RIFF WAVE data with 8, 16, 24 or 32-bit integer PCM or 32-bit float samples.
+func DecodeWAV(data []byte) (Audio, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return Audio{}, errors.New("not a RIFF WAVE file")
+	}
+
+	var (
+		format, bits uint16
+		audio        Audio
+		pcm          []byte
+		hasFmt       bool
+	)
+	for rest := data[12:]; len(rest) >= 8; {
+		id, size := string(rest[0:4]), int(binary.LittleEndian.Uint32(rest[4:8]))
+		rest = rest[8:]
+		if size > len(rest) {
+			return Audio{}, fmt.Errorf("truncated %q chunk", id)
+		}
+		chunk := rest[:size]
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return Audio{}, errors.New("invalid fmt chunk")
+			}
+			format = binary.LittleEndian.Uint16(chunk[0:2])
+			audio.Channels = int(binary.LittleEndian.Uint16(chunk[2:4]))
+			audio.SampleRate = int(binary.LittleEndian.Uint32(chunk[4:8]))
+			bits = binary.LittleEndian.Uint16(chunk[14:16])
+			hasFmt = true
+		case "data":
+			pcm = chunk
+		}
+		// Chunks are padded to even size.
+		rest = rest[min(size+size%2, len(rest)):]
+	}
+	if !hasFmt || pcm == nil {
+		return Audio{}, errors.New("missing fmt or data chunk")
+	}
+	if audio.Channels == 0 {
+		return Audio{}, errors.New("invalid channel count 0")
+	}
+
+	const (
+		formatPCM   = 1
+		formatFloat = 3
+	)
+	width := int(bits / 8)
+	switch {
+	case format == formatPCM && (bits == 8 || bits == 16 || bits == 24 || bits == 32):
+	case format == formatFloat && bits == 32:
+	default:
+		return Audio{}, fmt.Errorf("unsupported WAV format %d with %d bits per sample", format, bits)
+	}
+
+	audio.Samples = make([]float64, 0, len(pcm)/width)
+	for i := 0; i+width <= len(pcm); i += width {
+		b := pcm[i : i+width]
+		var s float64
+		switch {
+		case format == formatFloat:
+			s = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case bits == 8:
+			s = (float64(b[0]) - 128) / 128
+		case bits == 16:
+			s = float64(int16(binary.LittleEndian.Uint16(b))) / (1 << 15)
+		case bits == 24:
+			s = float64(int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24)>>8) / (1 << 23)
+		case bits == 32:
+			s = float64(int32(binary.LittleEndian.Uint32(b))) / (1 << 31)
+		}
+		audio.Samples = append(audio.Samples, s)
+	}
+	return audio, nil
+}
@@ -0,0 +1,67 @@
+package golden_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertAudio(t *testing.T) {
+	golden.AssertAudio(t, sineWAV(8000, 2, 0.5))
+}
+
+func TestDecodeWAV(t *testing.T) {
+	audio, err := golden.DecodeWAV(sineWAV(8000, 2, 0.25))
+	require.NoError(t, err)
+	assert.Equal(t, 8000, audio.SampleRate)
+	assert.Equal(t, 2, audio.Channels)
+	assert.Len(t, audio.Samples, 2*2000)
+
+	_, err = golden.DecodeWAV([]byte("not audio"))
+	assert.Error(t, err)
+
+	mt := &mockT{name: "TestDecodeWAV"}
+	assert.False(t, golden.AssertAudio(mt, []byte("RIFF\x00\x00\x00\x00WAVE")))
+	assert.Contains(t, mt.msg, "failed to decode WAV")
+}
+
+func TestAudioFingerprint(t *testing.T) {
+	audio := golden.Audio{SampleRate: 4, Channels: 1, Samples: []float64{1, -1, 1, -1, 0.5, -0.5, 0.5, -0.5, 0}}
+	assert.Equal(t, "duration: 2.25s\nsample rate: 4\nchannels: 1\nwindow: 1s\nrms:\n  1.000\n  0.500\n  0.000\n",
+		golden.AudioFingerprint(audio, golden.AudioOptions{Window: time.Second, Precision: 3}))
+}
+
+// sineWAV returns 16-bit PCM WAV with 440Hz sine wave fading out on the left channel and silence on the others.
+func sineWAV(sampleRate, channels int, seconds float64) []byte {
+	frames := int(float64(sampleRate) * seconds)
+	pcm := &bytes.Buffer{}
+	for i := range frames {
+		fade := 1 - float64(i)/float64(frames)
+		v := int16(math.Sin(2*math.Pi*440*float64(i)/float64(sampleRate)) * fade * math.MaxInt16)
+		_ = binary.Write(pcm, binary.LittleEndian, v)
+		for range channels - 1 {
+			_ = binary.Write(pcm, binary.LittleEndian, int16(0))
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("RIFF")
+	_ = binary.Write(buf, binary.LittleEndian, uint32(36+pcm.Len()))
+	buf.WriteString("WAVEfmt ")
+	for _, v := range []any{
+		uint32(16), uint16(1), uint16(channels), uint32(sampleRate),
+		uint32(sampleRate * channels * 2), uint16(channels * 2), uint16(16),
+	} {
+		_ = binary.Write(buf, binary.LittleEndian, v)
+	}
+	buf.WriteString("data")
+	_ = binary.Write(buf, binary.LittleEndian, uint32(pcm.Len()))
+	buf.Write(pcm.Bytes())
+	return buf.Bytes()
+}
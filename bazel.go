@@ -0,0 +1,60 @@
+package golden
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variables set by Bazel test runner, see https://bazel.build/reference/test-encyclopedia.
+const (
+	envTestSrcDir               = "TEST_SRCDIR"
+	envTestWorkspace            = "TEST_WORKSPACE"
+	envTestTarget               = "TEST_TARGET"
+	envTestUndeclaredOutputsDir = "TEST_UNDECLARED_OUTPUTS_DIR"
+)
+
+// bazelOutput describes where a recreated golden file is written when running under Bazel.
+type bazelOutput struct {
+	path   string // absolute path inside the undeclared outputs directory
+	source string // path of the golden file relative to the workspace root
+}
+
+// bazelOutputPath resolves the location for writing the recreated golden file when the tests are run under Bazel.
+// Bazel runs the tests inside read-only runfiles tree, so the golden files are written to TEST_UNDECLARED_OUTPUTS_DIR
+// using the workspace relative path which makes it easy to copy them back to the source tree.
+// Golden files are still read relative to the working directory, which is the package directory inside the runfiles.
+func bazelOutputPath(fileName string) (bazelOutput, bool) {
+	outDir := os.Getenv(envTestUndeclaredOutputsDir)
+	srcDir := os.Getenv(envTestSrcDir)
+	if outDir == "" || srcDir == "" {
+		return bazelOutput{}, false
+	}
+
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return bazelOutput{}, false
+	}
+
+	root := filepath.Join(srcDir, os.Getenv(envTestWorkspace))
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return bazelOutput{}, false
+	}
+
+	return bazelOutput{path: filepath.Join(outDir, rel), source: rel}, true
+}
+
+func (o bazelOutput) instructions() string {
+	pkg, target, _ := strings.Cut(strings.TrimPrefix(os.Getenv(envTestTarget), "//"), ":")
+	if target == "" {
+		target = filepath.Base(pkg)
+	}
+
+	return fmt.Sprintf("copy it back to the source tree with:\n"+
+		"\tunzip -o bazel-testlogs/%s/%s/test.outputs/outputs.zip %s -d \"$(bazel info workspace)\"\n"+
+		"or when using --nozip_undeclared_test_outputs:\n"+
+		"\tcp bazel-testlogs/%s/%s/test.outputs/%s \"$(bazel info workspace)/%s\"",
+		pkg, target, o.source, pkg, target, o.source, o.source)
+}
@@ -0,0 +1,56 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBazel(t *testing.T) {
+	srcDir := t.TempDir()
+	outDir := t.TempDir()
+	pkgDir := filepath.Join(srcDir, "_main", "some", "pkg")
+	require.NoError(t, os.MkdirAll(pkgDir, 0o755))
+
+	t.Chdir(pkgDir)
+	t.Setenv("TEST_SRCDIR", srcDir)
+	t.Setenv("TEST_WORKSPACE", "_main")
+	t.Setenv("TEST_TARGET", "//some/pkg:pkg_test")
+	t.Setenv("TEST_UNDECLARED_OUTPUTS_DIR", outDir)
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}
+
+	mt := &mockT{name: "TestBazel"}
+	assert.True(t, fh.Assert(mt, "data"))
+	assert.False(t, mt.failed)
+	assert.NoFileExists(t, filepath.Join(pkgDir, "testdata", "TestBazel", "TestBazel.golden"))
+
+	b, err := os.ReadFile(filepath.Join(outDir, "some", "pkg", "testdata", "TestBazel", "TestBazel.golden"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(b))
+	assert.Contains(t, mt.logs, "bazel-testlogs/some/pkg/pkg_test/test.outputs/outputs.zip some/pkg/testdata/TestBazel/TestBazel.golden")
+}
+
+func TestBazel_ReadFromRunfiles(t *testing.T) {
+	srcDir := t.TempDir()
+	pkgDir := filepath.Join(srcDir, "_main", "pkg")
+	require.NoError(t, os.MkdirAll(filepath.Join(pkgDir, "testdata", "TestBazel"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pkgDir, "testdata", "TestBazel", "TestBazel.golden"), []byte("data"), 0o600))
+
+	t.Chdir(pkgDir)
+	t.Setenv("TEST_SRCDIR", srcDir)
+	t.Setenv("TEST_WORKSPACE", "_main")
+	t.Setenv("TEST_UNDECLARED_OUTPUTS_DIR", t.TempDir())
+
+	mt := &mockT{name: "TestBazel"}
+	assert.True(t, golden.Assert(mt, "data"))
+	assert.False(t, mt.failed)
+}
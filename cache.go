@@ -0,0 +1,88 @@
+package golden
+
+import (
+	"sync"
+	"time"
+)
+
+// CachedStorage keeps the golden files in memory once read, so table tests asserting against the same large golden
+// file read it only once per run. The cached content is keyed by the name, the modification time and the size,
+// so files changed on disk are read again when the storage implements Stat(name string) (fs.FileInfo, error)
+// like OSStorage does, otherwise only the writes through the cache invalidate it.
+// The zero value is ready for use and it's safe for concurrent use:
+//
+//	golden.DefaultHandler.Storage = &golden.CachedStorage{}
+type CachedStorage struct {
+	// Storage stores the files, the OS filesystem is used when nil.
+	Storage Storage
+
+	mu    sync.Mutex
+	files map[string]cachedFile
+}
+
+type cachedFile struct {
+	modTime time.Time
+	size    int64
+	data    []byte
+}
+
+// ReadFile returns the cached content when the file hasn't changed, otherwise it reads the file and caches it.
+func (s *CachedStorage) ReadFile(name string) ([]byte, error) {
+	var modTime time.Time
+	size := int64(-1)
+	if st, ok := s.storage().(statStorage); ok {
+		info, err := st.Stat(name)
+		if err != nil {
+			s.invalidate(name)
+			return nil, err
+		}
+		modTime, size = info.ModTime(), info.Size()
+	}
+
+	s.mu.Lock()
+	f, ok := s.files[name]
+	s.mu.Unlock()
+	if ok && f.modTime.Equal(modTime) && f.size == size {
+		return append([]byte(nil), f.data...), nil
+	}
+
+	b, err := s.storage().ReadFile(name)
+	if err != nil {
+		s.invalidate(name)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.files == nil {
+		s.files = map[string]cachedFile{}
+	}
+	// the size reported by Stat is stored, it differs from the content length e.g. for the pointer files of DedupStorage
+	s.files[name] = cachedFile{modTime: modTime, size: size, data: b}
+	return append([]byte(nil), b...), nil
+}
+
+// WriteFile writes the file and drops it from the cache.
+func (s *CachedStorage) WriteFile(name string, data []byte) error {
+	s.invalidate(name)
+	return s.storage().WriteFile(name, data)
+}
+
+// Remove removes the file and drops it from the cache, the underlying storage must implement Remove(name string) error.
+func (s *CachedStorage) Remove(name string) error {
+	s.invalidate(name)
+	return removeStale(s.storage(), name)
+}
+
+func (s *CachedStorage) invalidate(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.files, name)
+}
+
+func (s *CachedStorage) storage() Storage {
+	if s.Storage == nil {
+		return OSStorage{}
+	}
+	return s.Storage
+}
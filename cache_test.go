@@ -0,0 +1,84 @@
+package golden_test
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingStorage struct {
+	golden.OSStorage
+	reads int
+}
+
+func (s *countingStorage) ReadFile(name string) ([]byte, error) {
+	s.reads++
+	return s.OSStorage.ReadFile(name)
+}
+
+func TestCachedStorage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	counter := &countingStorage{}
+	s := &golden.CachedStorage{Storage: counter}
+	name := "testdata/TestCached/TestCached.golden"
+
+	_, err := s.ReadFile(name)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	require.NoError(t, s.WriteFile(name, []byte("data")))
+	for range 3 {
+		b, err := s.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, "data", string(b))
+	}
+	assert.Equal(t, 1, counter.reads)
+
+	require.NoError(t, os.WriteFile(name, []byte("changed"), 0o600))
+	b, err := s.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(b))
+	assert.Equal(t, 2, counter.reads)
+
+	require.NoError(t, s.Remove(name))
+	_, err = s.ReadFile(name)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestCachedStorage_Assert(t *testing.T) {
+	t.Chdir(t.TempDir())
+	counter := &countingStorage{}
+	fh := &golden.FileHandler{
+		FileName:       func(golden.T) string { return "testdata/shared.golden" },
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		Storage:        &golden.CachedStorage{Storage: counter},
+	}
+	require.NoError(t, fh.Storage.WriteFile("testdata/shared.golden", []byte("data")))
+
+	for _, name := range []string{"TestCached/a", "TestCached/b", "TestCached/c"} {
+		mt := &mockT{name: name}
+		assert.True(t, fh.Assert(mt, "data"))
+		assert.False(t, mt.failed)
+	}
+	assert.Equal(t, 1, counter.reads)
+}
+
+func TestCachedStorage_Dedup(t *testing.T) {
+	t.Chdir(t.TempDir())
+	counter := &countingStorage{}
+	s := &golden.CachedStorage{Storage: golden.DedupStorage{Storage: counter}}
+	name := "testdata/TestCached/TestCached.golden"
+	require.NoError(t, s.WriteFile(name, []byte("stored as blob")))
+
+	counter.reads = 0
+	for range 3 {
+		b, err := s.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, "stored as blob", string(b))
+	}
+	assert.Equal(t, 2, counter.reads, "the pointer file and the blob are read once")
+}
@@ -0,0 +1,122 @@
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// ChunkedStorage splits the golden files larger than ChunkSize into parts named {name}.000, {name}.001 and so on
+// when writing and concatenates the parts when reading, to stay under the file size limits of Git hosting services.
+// Files which fit into a single chunk are stored as is. Switching between the layouts removes the stale files,
+// so the underlying storage must implement Remove(name string) error like OSStorage does.
+//
+//	golden.DefaultHandler.Storage = golden.ChunkedStorage{ChunkSize: 50 << 20}
+type ChunkedStorage struct {
+	// Storage stores the files and the parts, the OS filesystem is used when nil.
+	Storage Storage
+	// ChunkSize is the maximum size of the parts in bytes.
+	ChunkSize int
+}
+
+// ReadFile reads the file or concatenates its parts when the file doesn't exist.
+func (s ChunkedStorage) ReadFile(name string) ([]byte, error) {
+	b, err := s.storage().ReadFile(name)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return b, err
+	}
+
+	data, partErr := s.storage().ReadFile(chunkName(name, 0))
+	if errors.Is(partErr, fs.ErrNotExist) {
+		return nil, err
+	}
+	if partErr != nil {
+		return nil, partErr
+	}
+
+	for i := 1; ; i++ {
+		part, err := s.storage().ReadFile(chunkName(name, i))
+		if errors.Is(err, fs.ErrNotExist) {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, part...)
+	}
+}
+
+// WriteFile writes the file, or its parts when the data is larger than ChunkSize, and removes the stale files.
+func (s ChunkedStorage) WriteFile(name string, data []byte) error {
+	if s.ChunkSize <= 0 {
+		return fmt.Errorf("invalid chunk size: %d", s.ChunkSize)
+	}
+
+	if len(data) <= s.ChunkSize {
+		if err := s.storage().WriteFile(name, data); err != nil {
+			return err
+		}
+		return s.removeChunks(name, 0)
+	}
+
+	n := 0
+	for ; len(data) > 0; n++ {
+		size := min(s.ChunkSize, len(data))
+		if err := s.storage().WriteFile(chunkName(name, n), data[:size]); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+	if err := s.remove(name); err != nil {
+		return err
+	}
+	return s.removeChunks(name, n)
+}
+
+// Remove removes the file and all of its parts.
+func (s ChunkedStorage) Remove(name string) error {
+	if err := s.remove(name); err != nil {
+		return err
+	}
+	return s.removeChunks(name, 0)
+}
+
+// removeChunks removes the parts of the file starting from the given index.
+func (s ChunkedStorage) removeChunks(name string, from int) error {
+	for i := from; ; i++ {
+		if !fileExists(s.storage(), chunkName(name, i)) {
+			return nil
+		}
+		if err := s.remove(chunkName(name, i)); err != nil {
+			return err
+		}
+	}
+}
+
+func (s ChunkedStorage) remove(name string) error {
+	return removeStale(s.storage(), name)
+}
+
+// removeStale removes the file if it exists, the storage must implement Remove(name string) error.
+func removeStale(s Storage, name string) error {
+	if !fileExists(s, name) {
+		return nil
+	}
+
+	r, ok := s.(interface{ Remove(name string) error })
+	if !ok {
+		return fmt.Errorf("stale golden file %s can't be removed, storage %T doesn't implement Remove", name, s)
+	}
+	return r.Remove(name)
+}
+
+func (s ChunkedStorage) storage() Storage {
+	if s.Storage == nil {
+		return OSStorage{}
+	}
+	return s.Storage
+}
+
+func chunkName(name string, i int) string {
+	return fmt.Sprintf("%s.%03d", name, i)
+}
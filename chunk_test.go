@@ -0,0 +1,82 @@
+package golden_test
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkedStorage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	s := golden.ChunkedStorage{ChunkSize: 4}
+	name := "testdata/TestChunked/TestChunked.golden"
+
+	require.NoError(t, s.WriteFile(name, []byte("0123456789")))
+	assert.NoFileExists(t, name)
+	for part, want := range map[string]string{".000": "0123", ".001": "4567", ".002": "89"} {
+		b, err := os.ReadFile(name + part)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(b))
+	}
+	b, err := s.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(b))
+
+	require.NoError(t, s.WriteFile(name, []byte("abcdef")))
+	assert.NoFileExists(t, name+".002", "stale part is removed")
+	b, err = s.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", string(b))
+
+	require.NoError(t, s.WriteFile(name, []byte("abc")))
+	assert.NoFileExists(t, name+".000")
+	assert.FileExists(t, name)
+	b, err = s.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", string(b))
+
+	require.NoError(t, s.Remove(name))
+	_, err = s.ReadFile(name)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestChunkedStorage_Assert(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Storage:        golden.ChunkedStorage{ChunkSize: 16},
+	}
+
+	data := strings.Repeat("line\n", 10)
+	assert.True(t, fh.Assert(&mockT{name: "TestChunked"}, data))
+	assert.FileExists(t, "testdata/TestChunked/TestChunked.golden.003")
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt := &mockT{name: "TestChunked"}
+	assert.True(t, fh.Assert(mt, data))
+	assert.False(t, mt.failed)
+}
+
+func TestChunkedStorage_WriteWithoutReading(t *testing.T) {
+	t.Chdir(t.TempDir())
+	counter := &countingStorage{}
+	s := golden.ChunkedStorage{Storage: counter, ChunkSize: 4}
+	name := "testdata/TestChunked/TestChunked.golden"
+
+	require.NoError(t, s.WriteFile(name, []byte("0123456789")))
+	require.NoError(t, s.WriteFile(name, []byte("abc")))
+	assert.NoFileExists(t, name+".000")
+	assert.Zero(t, counter.reads, "the parts are checked with Stat")
+
+	d := golden.DedupStorage{Storage: counter}
+	require.NoError(t, d.WriteFile("testdata/TestDedup/a.golden", []byte("blob")))
+	require.NoError(t, d.WriteFile("testdata/TestDedup/b.golden", []byte("blob")))
+	assert.Zero(t, counter.reads, "the blob is checked with Stat")
+}
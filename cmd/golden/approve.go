@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/go-tstr/golden"
+)
+
+// runApprove promotes the pending golden files written with WritePending:
+//
+//	golden approve [-n] [dir ...]
+func runApprove(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("approve", flag.ContinueOnError)
+	dryRun := fs.Bool("n", false, "only list the pending golden files without approving them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dirs := fs.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	for _, dir := range dirs {
+		if *dryRun {
+			pending, err := golden.PendingFiles(dir)
+			if err != nil {
+				return err
+			}
+			for _, p := range pending {
+				fmt.Fprintf(stdout, "pending %s\n", p)
+			}
+			continue
+		}
+
+		approved, err := golden.Approve(dir)
+		for _, f := range approved {
+			fmt.Fprintf(stdout, "approved %s\n", f)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
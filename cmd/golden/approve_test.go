@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprove(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestA", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestA/a.golden", []byte("old"), 0o600))
+	require.NoError(t, os.WriteFile("testdata/TestA/a.golden.pending", []byte("new"), 0o600))
+	require.NoError(t, os.WriteFile("testdata/TestA/b.golden.pending", []byte("created"), 0o600))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, run([]string{"approve", "-n"}, out))
+	assert.Equal(t, "pending testdata/TestA/a.golden.pending\npending testdata/TestA/b.golden.pending\n", out.String())
+	assert.FileExists(t, "testdata/TestA/a.golden.pending")
+
+	out.Reset()
+	require.NoError(t, run([]string{"approve", "testdata"}, out))
+	assert.Equal(t, "approved testdata/TestA/a.golden\napproved testdata/TestA/b.golden\n", out.String())
+	assert.NoFileExists(t, "testdata/TestA/a.golden.pending")
+	b, err := os.ReadFile("testdata/TestA/a.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(b))
+}
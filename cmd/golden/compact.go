@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/go-tstr/golden"
+)
+
+// runCompact deduplicates the identical golden files into shared blobs and, with -prune, removes the unreferenced blobs:
+//
+//	golden compact [-prune] [dir ...]
+func runCompact(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("compact", flag.ContinueOnError)
+	prune := fs.Bool("prune", false, "remove the blobs no pointer file references, run it from the module root")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dirs := fs.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	for _, dir := range dirs {
+		res, err := golden.CompactBlobs(dir, golden.CompactOptions{Prune: *prune})
+		if err != nil {
+			return err
+		}
+		for _, f := range res.Deduplicated {
+			fmt.Fprintf(stdout, "deduplicated %s\n", f)
+		}
+		for _, f := range res.Removed {
+			fmt.Fprintf(stdout, "removed %s\n", f)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompact(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestA", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestA/a.golden", []byte("same"), 0o600))
+	require.NoError(t, os.WriteFile("testdata/TestA/b.golden", []byte("same"), 0o600))
+	require.NoError(t, os.WriteFile("testdata/TestA/c.golden", []byte("unique"), 0o600))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, run([]string{"compact"}, out))
+	assert.Equal(t, "deduplicated testdata/TestA/a.golden\ndeduplicated testdata/TestA/b.golden\n", out.String())
+	assert.FileExists(t, "testdata/.blobs/0967115f2813a3541eaef77de9d9d5773f1c0c04314b0bbfe4ff3b3b1c55b5d5")
+
+	require.NoError(t, os.WriteFile("testdata/TestA/a.golden", []byte("rewritten"), 0o600))
+	require.NoError(t, os.WriteFile("testdata/TestA/b.golden", []byte("rewritten twice"), 0o600))
+	out.Reset()
+	require.NoError(t, run([]string{"compact", "-prune"}, out))
+	assert.Equal(t, "removed testdata/.blobs/0967115f2813a3541eaef77de9d9d5773f1c0c04314b0bbfe4ff3b3b1c55b5d5\n", out.String())
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-tstr/golden"
+)
+
+// runCoverage reports which endpoints and status codes of an OpenAPI spec have golden coverage:
+//
+//	golden coverage -spec openapi.yaml [-base /api/v1] requests.jsonl ...
+func runCoverage(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	specPath := fs.String("spec", "", "OpenAPI spec in JSON or YAML format")
+	basePath := fs.String("base", "", "base path stripped from the recorded request paths")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *specPath == "" || fs.NArg() == 0 {
+		return errors.New("usage: golden coverage -spec openapi.yaml [-base /api/v1] requests.jsonl ...")
+	}
+
+	spec, err := os.ReadFile(*specPath)
+	if err != nil {
+		return err
+	}
+
+	var records []golden.RequestRecord
+	for _, path := range fs.Args() {
+		r, err := golden.ReadRequestRecords(path)
+		if err != nil {
+			return err
+		}
+		records = append(records, r...)
+	}
+
+	report, err := golden.APICoverage(spec, *basePath, records)
+	if err != nil {
+		return err
+	}
+
+	covered, total := 0, 0
+	for _, e := range report.Endpoints {
+		covered += len(e.Covered)
+		total += len(e.Covered) + len(e.Missing)
+
+		fmt.Fprintf(stdout, "%-7s %s\n", e.Method, e.Path)
+		if len(e.Covered) > 0 {
+			fmt.Fprintf(stdout, "        covered: %s\n", strings.Join(e.Covered, ", "))
+		}
+		if len(e.Missing) > 0 {
+			fmt.Fprintf(stdout, "        missing: %s\n", strings.Join(e.Missing, ", "))
+		}
+	}
+
+	if len(report.Unknown) > 0 {
+		fmt.Fprintln(stdout, "\nrequests not described in the spec:")
+		for _, r := range report.Unknown {
+			fmt.Fprintf(stdout, "  %s %s %d (%s)\n", r.Method, r.Path, r.StatusCode, r.Test)
+		}
+	}
+
+	if total > 0 {
+		fmt.Fprintf(stdout, "\n%d/%d responses covered (%.1f%%)\n", covered, total, float64(covered)/float64(total)*100)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverage(t *testing.T) {
+	recorder := &golden.RequestRecorder{}
+	recorder.Record(golden.RequestRecord{Test: "TestUsers/list", Method: "GET", Path: "/users", StatusCode: 200})
+	recorder.Record(golden.RequestRecord{Test: "TestUsers/missing", Method: "GET", Path: "/users/1", StatusCode: 404})
+	recorder.Record(golden.RequestRecord{Test: "TestUsers/delete", Method: "DELETE", Path: "/users/1", StatusCode: 204})
+	records := filepath.Join(t.TempDir(), "requests.jsonl")
+	require.NoError(t, recorder.WriteFile(records))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, run([]string{"coverage", "-spec", "../../testdata/openapi/openapi.yaml", records}, out))
+	golden.Assert(t, out.String())
+}
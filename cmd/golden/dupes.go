@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/go-tstr/golden"
+)
+
+// runDupes reports golden files with identical or near-identical content:
+//
+//	golden dupes [-similarity 0.9] [dir ...]
+func runDupes(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("dupes", flag.ContinueOnError)
+	similarity := fs.Float64("similarity", 0.9, "minimum share of common lines for reporting near-identical files, 1 reports only identical files")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dirs := fs.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	for _, dir := range dirs {
+		groups, err := golden.FindDuplicates(dir, *similarity)
+		if err != nil {
+			return err
+		}
+
+		for _, g := range groups {
+			if g.Similarity == 1 {
+				fmt.Fprintf(stdout, "identical content in %d files, consider using a shared fixture:\n", len(g.Files))
+			} else {
+				fmt.Fprintf(stdout, "%.0f%% similar content, consider using a shared fixture or a parameterized golden file:\n", g.Similarity*100)
+			}
+			for _, f := range g.Files {
+				fmt.Fprintf(stdout, "  %s\n", f)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDupes(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestA", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestA/a.golden", []byte("a\nb\nc\nd\ne\n"), 0o600))
+	require.NoError(t, os.WriteFile("testdata/TestA/b.golden", []byte("a\nb\nc\nd\ne\n"), 0o600))
+	require.NoError(t, os.WriteFile("testdata/TestA/c.golden", []byte("a\nb\nc\nd\nx\n"), 0o600))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, run([]string{"dupes", "-similarity", "0.8"}, out))
+	assert.Equal(t, `identical content in 2 files, consider using a shared fixture:
+  testdata/TestA/a.golden
+  testdata/TestA/b.golden
+83% similar content, consider using a shared fixture or a parameterized golden file:
+  testdata/TestA/a.golden
+  testdata/TestA/c.golden
+`, out.String())
+}
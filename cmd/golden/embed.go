@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"text/template"
+)
+
+var embedTmpl = template.Must(template.New("embed").Parse(`// Code generated by golden embed. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"embed"
+
+	"github.com/go-tstr/golden"
+)
+
+//go:embed all:{{ .Dir }}
+var {{ .Var }}FS embed.FS
+
+// {{ .Var }} reads the golden files from the copy of {{ .Dir }} embedded into the test binary.
+var {{ .Var }} = golden.DefaultHandler.WithFS({{ .Var }}FS)
+`))
+
+type embedConfig struct {
+	Package string
+	Dir     string
+	Var     string
+	Output  string
+}
+
+// runEmbed generates a test file embedding the testdata directory, it's intended to be used with go:generate:
+//
+//	//go:generate go run github.com/go-tstr/golden/cmd/golden embed
+func runEmbed(args []string, _ io.Writer) error {
+	cfg := embedConfig{}
+	fs := flag.NewFlagSet("embed", flag.ContinueOnError)
+	fs.StringVar(&cfg.Package, "pkg", os.Getenv("GOPACKAGE"), "package name of the generated file, defaults to $GOPACKAGE set by go generate")
+	fs.StringVar(&cfg.Dir, "dir", "testdata", "directory to embed")
+	fs.StringVar(&cfg.Var, "var", "goldenHandler", "name of the generated handler variable")
+	fs.StringVar(&cfg.Output, "o", "golden_embed_test.go", "output file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if cfg.Package == "" {
+		return errors.New("package name is required, use -pkg or run with go generate")
+	}
+
+	src, err := generateEmbed(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cfg.Output, src, 0o600)
+}
+
+func generateEmbed(cfg embedConfig) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := embedTmpl.Execute(buf, cfg); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	return format.Source(buf.Bytes())
+}
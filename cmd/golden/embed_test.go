@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbed(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "golden_embed_test.go")
+	require.NoError(t, run([]string{"embed", "-pkg", "foo_test", "-o", out}, &bytes.Buffer{}))
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	golden.Assert(t, string(b))
+}
+
+func TestEmbed_NoPackage(t *testing.T) {
+	t.Setenv("GOPACKAGE", "")
+	err := run([]string{"embed", "-o", filepath.Join(t.TempDir(), "out.go")}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "package name is required")
+}
+
+func TestUnknownCommand(t *testing.T) {
+	err := run([]string{"unknown"}, &bytes.Buffer{})
+	require.ErrorIs(t, err, errUsage)
+	assert.ErrorContains(t, err, "embed")
+}
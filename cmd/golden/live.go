@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-tstr/golden"
+)
+
+// runLive re-executes the live-verifiable requests against a deployment and reports drift from the golden files:
+//
+//	golden live -base https://staging.example.com [-root .] [-H "Authorization: Bearer ..."] live.jsonl ...
+func runLive(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("live", flag.ContinueOnError)
+	baseURL := fs.String("base", "", "base URL of the deployment the requests are sent to")
+	root := fs.String("root", ".", "module root the golden file paths are relative to")
+	header := http.Header{}
+	fs.Func("H", "header added to every request, e.g. \"Authorization: Bearer token\", can be repeated", func(s string) error {
+		name, value, ok := strings.Cut(s, ":")
+		if !ok {
+			return fmt.Errorf("invalid header %q, expected \"Name: value\"", s)
+		}
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *baseURL == "" || fs.NArg() == 0 {
+		return errors.New("usage: golden live -base https://staging.example.com [-root .] [-H header] live.jsonl ...")
+	}
+
+	var requests []golden.LiveRequest
+	for _, path := range fs.Args() {
+		r, err := golden.ReadLiveRequests(path)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, r...)
+	}
+
+	drift := golden.VerifyLive(http.DefaultClient, *baseURL, header, *root, requests)
+	for _, d := range drift {
+		fmt.Fprintf(stdout, "%s %s (%s)\n", d.Request.Method, d.Request.Path, d.Request.Test)
+		switch {
+		case d.Err != nil:
+			fmt.Fprintf(stdout, "  error: %s\n", d.Err)
+			continue
+		case d.StatusCode != d.Request.StatusCode:
+			fmt.Fprintf(stdout, "  status code: expected %d, got %d\n", d.Request.StatusCode, d.StatusCode)
+		}
+		if d.Diff != "" {
+			fmt.Fprintf(stdout, "  %s\n", strings.ReplaceAll(strings.TrimSuffix(d.Diff, "\n"), "\n", "\n  "))
+		}
+	}
+
+	fmt.Fprintf(stdout, "%d/%d requests match the golden files\n", len(requests)-len(drift), len(requests))
+	if len(drift) > 0 {
+		return fmt.Errorf("%d requests drifted from the golden files", len(drift))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLive(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/users":
+			_, _ = w.Write([]byte(`{"users": [{"name": "someone"}]}`))
+		case "/version":
+			_, _ = w.Write([]byte("v2\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "testdata", "TestAPI"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "testdata", "TestAPI", "users.golden"), []byte("{\n  \"users\": [{\"name\": \"someone\"}]\n}\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "testdata", "TestAPI", "version.golden"), []byte("v1\n"), 0o600))
+
+	recorder := &golden.LiveRecorder{}
+	recorder.Record(golden.LiveRequest{Test: "TestAPI/users", GoldenFile: "testdata/TestAPI/users.golden", Method: "GET", Path: "/users", StatusCode: 200})
+	recorder.Record(golden.LiveRequest{Test: "TestAPI/version", GoldenFile: "testdata/TestAPI/version.golden", Method: "GET", Path: "/version", StatusCode: 200})
+	recorder.Record(golden.LiveRequest{Test: "TestAPI/missing", GoldenFile: "testdata/TestAPI/version.golden", Method: "GET", Path: "/missing", StatusCode: 200})
+	requests := filepath.Join(t.TempDir(), "live.jsonl")
+	require.NoError(t, recorder.WriteFile(requests))
+
+	out := &bytes.Buffer{}
+	err := run([]string{"live", "-base", srv.URL, "-root", root, "-H", "Authorization: Bearer secret", requests}, out)
+	assert.EqualError(t, err, "2 requests drifted from the golden files")
+	golden.Assert(t, out.String())
+}
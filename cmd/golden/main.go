@@ -0,0 +1,74 @@
+// Command golden provides tooling for managing golden files.
+//
+// Usage:
+//
+//	golden <command> [flags]
+//
+// Commands:
+//
+//	approve  promote the pending golden files written with WritePending
+//	compact  deduplicate identical golden files into shared blobs, -prune removes the unreferenced blobs
+//	coverage report which endpoints of an OpenAPI spec have golden coverage
+//	dupes    report golden files with identical or near-identical content
+//	embed    generate a test file which embeds the package testdata into the test binary
+//	live     re-execute live-verifiable requests and report drift from the golden files
+//	rename   move golden files of renamed tests
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+type command struct {
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"approve":  {usage: "promote the pending golden files written with WritePending", run: runApprove},
+	"compact":  {usage: "deduplicate identical golden files into shared blobs, -prune removes the unreferenced blobs", run: runCompact},
+	"coverage": {usage: "report which endpoints of an OpenAPI spec have golden coverage", run: runCoverage},
+	"dupes":    {usage: "report golden files with identical or near-identical content", run: runDupes},
+	"embed":    {usage: "generate a test file which embeds the package testdata into the test binary", run: runEmbed},
+	"live":     {usage: "re-execute live-verifiable requests and report drift from the golden files", run: runLive},
+	"rename":   {usage: "move golden files of renamed tests", run: runRename},
+}
+
+var errUsage = errors.New("usage: golden <command> [flags]")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return usageError()
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n%w", args[0], usageError())
+	}
+	return cmd.run(args[1:], stdout)
+}
+
+func usageError() error {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msg := ""
+	for _, name := range names {
+		msg += fmt.Sprintf("\n  %-8s %s", name, commands[name].usage)
+	}
+	return fmt.Errorf("%w\n\ncommands:%s", errUsage, msg)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-tstr/golden"
+)
+
+// runRename moves golden files of renamed tests so that they don't get orphaned:
+//
+//	golden rename [-dir testdata] [-manifest testdata/golden.tracked] [-n] TestOld=TestNew ...
+//	golden rename -from old.tracked -to new.tracked [-n]
+func runRename(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("rename", flag.ContinueOnError)
+	dir := fs.String("dir", "testdata", "golden files directory")
+	manifest := fs.String("manifest", "", "manifest file written by golden.Tracker, it's updated after the rename")
+	from := fs.String("from", "", "manifest of the previous tracked run, used for detecting renamed tests")
+	to := fs.String("to", "", "manifest of the current tracked run, used for detecting renamed tests")
+	dryRun := fs.Bool("n", false, "print the moves without applying them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	renames := map[string]string{}
+	for _, arg := range fs.Args() {
+		oldName, newName, ok := strings.Cut(arg, "=")
+		if !ok || oldName == "" || newName == "" {
+			return fmt.Errorf("invalid rename %q, expected OldTestName=NewTestName", arg)
+		}
+		renames[oldName] = newName
+	}
+
+	var entries map[string]string
+	switch {
+	case *from != "" || *to != "":
+		if *from == "" || *to == "" || len(renames) > 0 {
+			return errors.New("-from and -to must be used together and without explicit renames")
+		}
+
+		previous, err := golden.ReadManifest(*from)
+		if err != nil {
+			return err
+		}
+		current, err := golden.ReadManifest(*to)
+		if err != nil {
+			return err
+		}
+		renames = golden.DiffManifests(previous, current)
+		entries = previous
+	case *manifest != "":
+		var err error
+		if entries, err = golden.ReadManifest(*manifest); err != nil {
+			return err
+		}
+	}
+
+	if len(renames) == 0 {
+		return errors.New("no renames given")
+	}
+
+	moves, err := golden.PlanRename(*dir, renames, entries)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range moves {
+		fmt.Fprintf(stdout, "%s -> %s\n", m.From, m.To)
+	}
+	if *dryRun {
+		return nil
+	}
+
+	if err := golden.ApplyMoves(moves); err != nil {
+		return err
+	}
+	if *manifest != "" {
+		return golden.WriteManifest(*manifest, golden.RenameManifest(*dir, entries, renames))
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRename(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestOld", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestOld/sub.golden", []byte("data"), 0o600))
+	require.NoError(t, golden.WriteManifest("testdata/golden.tracked", map[string]string{"TestOld/sub": "testdata/TestOld/sub.golden"}))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, run([]string{"rename", "-manifest", "testdata/golden.tracked", "TestOld=TestNew"}, out))
+	assert.Equal(t, "testdata/TestOld/sub.golden -> testdata/TestNew/sub.golden\n", out.String())
+	assert.FileExists(t, filepath.Join("testdata", "TestNew", "sub.golden"))
+
+	entries, err := golden.ReadManifest("testdata/golden.tracked")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"TestNew/sub": "testdata/TestNew/sub.golden"}, entries)
+}
+
+func TestRename_FromTrackedRuns(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestOld", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestOld/sub.golden", []byte("data"), 0o600))
+	require.NoError(t, golden.WriteManifest("old.tracked", map[string]string{"TestOld/sub": "testdata/TestOld/sub.golden"}))
+	require.NoError(t, golden.WriteManifest("new.tracked", map[string]string{"TestNew/sub": "testdata/TestNew/sub.golden"}))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, run([]string{"rename", "-from", "old.tracked", "-to", "new.tracked", "-n"}, out))
+	assert.Equal(t, "testdata/TestOld/sub.golden -> testdata/TestNew/sub.golden\n", out.String())
+	assert.FileExists(t, filepath.Join("testdata", "TestOld", "sub.golden"), "dry run doesn't move files")
+}
+
+func TestRename_InvalidArgs(t *testing.T) {
+	assert.ErrorContains(t, run([]string{"rename", "TestOld"}, &bytes.Buffer{}), "invalid rename")
+	assert.ErrorContains(t, run([]string{"rename"}, &bytes.Buffer{}), "no renames given")
+	assert.ErrorContains(t, run([]string{"rename", "-from", "a"}, &bytes.Buffer{}), "-from and -to must be used together")
+}
@@ -0,0 +1,57 @@
+package golden
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+)
+
+// ErrGoldenCollision is returned when two tests recreate the same golden file with different content during the run,
+// e.g. when their names differ only in characters which are sanitized from the file name.
+// Tests which intentionally share a golden file, e.g. with AssertFile or SharedFixture, are fine as long as their content matches.
+var ErrGoldenCollision = errors.New("golden file recreated by multiple tests with different content")
+
+var recreatedFiles = &collisionDetector{files: map[string]recreatedFile{}}
+
+// collisionDetector remembers the golden files recreated during the run and the test which wrote them.
+type collisionDetector struct {
+	mu    sync.Mutex
+	files map[string]recreatedFile
+}
+
+type recreatedFile struct {
+	test string
+	hash [sha256.Size]byte
+}
+
+// check fails when another test already recreated the golden file with different content, it's done before writing
+// the file, so the content written by the other test isn't overwritten.
+func (d *collisionDetector) check(test, fileName, content string) error {
+	return d.do(test, fileName, content, false)
+}
+
+// claim records that the test recreated the golden file with the content, it's done after the file was written,
+// so vetoed and failed writes aren't recorded. It fails like check when another test recreated the file meanwhile.
+func (d *collisionDetector) claim(test, fileName, content string) error {
+	return d.do(test, fileName, content, true)
+}
+
+func (d *collisionDetector) do(test, fileName, content string, record bool) error {
+	path, err := filepath.Abs(fileName)
+	if err != nil {
+		return err
+	}
+	file := recreatedFile{test: test, hash: sha256.Sum256([]byte(content))}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if prev, ok := d.files[path]; ok && prev.test != test && prev.hash != file.hash {
+		return fmt.Errorf("%w: %s is used by tests %q and %q, rename one of the tests or give it its own golden file", ErrGoldenCollision, fileName, prev.test, test)
+	}
+	if record {
+		d.files[path] = file
+	}
+	return nil
+}
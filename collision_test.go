@@ -0,0 +1,54 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollision(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}
+
+	mt := &mockT{name: "TestCollision/user a"}
+	assert.True(t, fh.Assert(mt, "a"))
+	mt = &mockT{name: "TestCollision/user a"}
+	assert.True(t, fh.Assert(mt, "changed"), "same test may rewrite its golden file")
+
+	mt = &mockT{name: "TestCollision/user_a"}
+	assert.False(t, fh.Assert(mt, "b"))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, golden.ErrGoldenCollision.Error())
+	assert.Contains(t, mt.msg, `"TestCollision/user a" and "TestCollision/user_a"`)
+
+	mt = &mockT{name: "TestCollision/user_a"}
+	assert.True(t, fh.Assert(mt, "changed"), "sharing the golden file with the same content is fine")
+	assert.False(t, mt.failed)
+}
+
+func TestCollision_VetoedRecreate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		OnRecreate: func(t golden.T, _ string, _, _ []byte) {
+			if t.Name() == "TestVeto/user a" {
+				t.Errorf("recreating is refused")
+			}
+		},
+	}
+
+	mt := &mockT{name: "TestVeto/user a"}
+	assert.False(t, fh.Assert(mt, "a"))
+	assert.True(t, mt.failed)
+
+	mt = &mockT{name: "TestVeto/user_a"}
+	assert.True(t, fh.Assert(mt, "b"), "the vetoed recreation doesn't claim the golden file")
+	assert.False(t, mt.failed, mt.msg)
+}
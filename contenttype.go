@@ -0,0 +1,124 @@
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+)
+
+// ContentType describes how the content of a specific type is detected, processed and compared.
+type ContentType struct {
+	// Name of the content type, e.g. "json".
+	Name string
+	// Sniff reports whether the data is of this content type.
+	Sniff func(data []byte) bool
+	// ProcessContent is used instead of FileHandler.ProcessContent when set.
+	ProcessContent func(T, string) string
+	// Equal is used instead of FileHandler.Equal when set.
+	Equal func(t T, expected, actual string, msgAndArgs ...interface{}) (ok bool)
+}
+
+// ContentTypes is a registry of content types used by FileHandler for selecting the processing and comparison
+// per assertion based on the actual data. The content types are sniffed in the registration order
+// and the first match wins. ContentTypes is safe for concurrent use.
+type ContentTypes struct {
+	mu    sync.RWMutex
+	types []ContentType
+}
+
+// NewContentTypes creates a registry with the given content types.
+func NewContentTypes(types ...ContentType) *ContentTypes {
+	return &ContentTypes{types: types}
+}
+
+// DefaultContentTypes returns a registry which detects the content types registered with RegisterContentType
+// followed by binary, JSON, XML and YAML content. It can be enabled for the default handler with:
+//
+//	golden.DefaultHandler.ContentTypes = golden.DefaultContentTypes()
+func DefaultContentTypes() *ContentTypes {
+	return NewContentTypes(append(RegisteredContentTypes(), BinaryContentType, JSONContentType, XMLContentType, YAMLContentType)...)
+}
+
+// Register adds the content type to the registry, it's sniffed after the previously registered content types.
+func (r *ContentTypes) Register(ct ContentType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types = append(r.types, ct)
+}
+
+// Lookup returns the first content type which matches the data.
+func (r *ContentTypes) Lookup(data []byte) (ContentType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, ct := range r.types {
+		if ct.Sniff(data) {
+			return ct, true
+		}
+	}
+	return ContentType{}, false
+}
+
+var (
+	// BinaryContentType matches content which isn't valid UTF-8 or contains NUL bytes and compares it with EqualBinary.
+	BinaryContentType = ContentType{Name: "binary", Sniff: IsBinary, Equal: EqualBinary}
+	// JSONContentType matches JSON objects and arrays and formats them with PrettyJSON.
+	JSONContentType = ContentType{Name: "json", Sniff: IsJSON, ProcessContent: PrettyJSON}
+	// XMLContentType matches XML documents and formats them with PrettyXML.
+	XMLContentType = ContentType{Name: "xml", Sniff: IsXML, ProcessContent: PrettyXML}
+	// YAMLContentType matches YAML documents starting with a document marker or directive and formats them with PrettyYAML.
+	YAMLContentType = ContentType{Name: "yaml", Sniff: IsYAML, ProcessContent: PrettyYAML}
+)
+
+// IsBinary reports whether the data isn't valid UTF-8 or contains NUL bytes.
+func IsBinary(data []byte) bool {
+	return !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0
+}
+
+// IsJSON reports whether the data is a valid JSON object or array.
+func IsJSON(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && (data[0] == '{' || data[0] == '[') && json.Valid(data)
+}
+
+// IsXML reports whether the data is a well-formed XML document.
+func IsXML(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '<' && validateXML(string(data)) == nil
+}
+
+// IsYAML reports whether the data starts with YAML document marker "---" or a YAML directive.
+// Plain text is valid YAML too, so YAML without explicit marker isn't detected.
+func IsYAML(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return bytes.HasPrefix(data, []byte("---")) || bytes.HasPrefix(data, []byte("%YAML"))
+}
+
+// EqualBinary compares the data byte by byte without rendering a diff,
+// the failure message contains the sizes and the offset of the first differing byte.
+func EqualBinary(t T, expected, actual string, msgAndArgs ...interface{}) (ok bool) {
+	t.Helper()
+	if expected == actual {
+		return true
+	}
+
+	offset := 0
+	for offset < len(expected) && offset < len(actual) && expected[offset] == actual[offset] {
+		offset++
+	}
+
+	msg := fmt.Sprintf("binary content differs: expected %d bytes, actual %d bytes, first difference at offset %d", len(expected), len(actual), offset)
+	if len(msgAndArgs) > 0 {
+		msg += "\n" + messageFromMsgAndArgs(msgAndArgs...)
+	}
+	t.Errorf("%s", msg)
+	return false
+}
+
+func messageFromMsgAndArgs(msgAndArgs ...interface{}) string {
+	if format, ok := msgAndArgs[0].(string); ok && len(msgAndArgs) > 1 {
+		return fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+	return fmt.Sprint(msgAndArgs...)
+}
@@ -0,0 +1,72 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentTypes(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestContentTypes"), "failed to remove testdata") })
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		ContentTypes:   golden.DefaultContentTypes(),
+	}
+
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{name: "json", data: `{"a":1}`, expected: "{\n  \"a\": 1\n}\n"},
+		{name: "xml", data: `<a><b>1</b></a>`, expected: "<a>\n  <b>1</b>\n</a>\n"},
+		{name: "yaml", data: "---\na:   1\n", expected: "a: 1\n"},
+		{name: "binary", data: "\x00\x01", expected: "\x00\x01"},
+		{name: "text", data: "plain {text}", expected: "plain {text}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockT{name: "TestContentTypes/" + tt.name}
+			assert.True(t, fh.Assert(mt, tt.data))
+			assert.False(t, mt.failed)
+
+			b, err := os.ReadFile("./testdata/TestContentTypes/" + tt.name + ".golden")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(b))
+		})
+	}
+}
+
+func TestContentTypes_Register(t *testing.T) {
+	r := golden.NewContentTypes()
+	_, ok := r.Lookup([]byte("data"))
+	assert.False(t, ok)
+
+	r.Register(golden.ContentType{Name: "any", Sniff: func([]byte) bool { return true }})
+	ct, ok := r.Lookup([]byte("data"))
+	assert.True(t, ok)
+	assert.Equal(t, "any", ct.Name)
+}
+
+func TestEqualBinary(t *testing.T) {
+	mt := &mockT{name: "TestEqualBinary"}
+	assert.True(t, golden.EqualBinary(mt, "\x00\x01", "\x00\x01"))
+	assert.False(t, golden.EqualBinary(mt, "\x00\x01\x02", "\x00\x02", "file a.golden"))
+	assert.Contains(t, mt.msg, "expected 3 bytes, actual 2 bytes, first difference at offset 1\nfile a.golden")
+}
+
+func TestSniffers(t *testing.T) {
+	assert.True(t, golden.IsJSON([]byte(" [1, 2] ")))
+	assert.False(t, golden.IsJSON([]byte(`"string"`)))
+	assert.True(t, golden.IsXML([]byte(`<?xml version="1.0"?><a/>`)))
+	assert.False(t, golden.IsXML([]byte(`<a>`)))
+	assert.True(t, golden.IsYAML([]byte("%YAML 1.2\n---\na: 1")))
+	assert.False(t, golden.IsYAML([]byte("a: 1")))
+	assert.True(t, golden.IsBinary([]byte{0xff, 0xfe}))
+	assert.False(t, golden.IsBinary([]byte("text")))
+}
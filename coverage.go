@@ -0,0 +1,236 @@
+package golden
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RequestRecord describes a single Request assertion.
+type RequestRecord struct {
+	Test       string `json:"test"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	StatusCode int    `json:"status_code"`
+}
+
+// RequestRecorder collects the Request assertions for reporting API coverage, see APICoverage.
+// RequestRecorder is safe for concurrent use and it's intended to be set up in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		recorder := &golden.RequestRecorder{}
+//		golden.DefaultHandler.RequestRecorder = recorder
+//
+//		code := m.Run()
+//		if err := recorder.WriteFile("../coverage/requests.jsonl"); err != nil {
+//			log.Fatal(err)
+//		}
+//		os.Exit(code)
+//	}
+type RequestRecorder struct {
+	mu      sync.Mutex
+	records []RequestRecord
+}
+
+// Record stores the record.
+func (r *RequestRecorder) Record(rec RequestRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, rec)
+}
+
+// Records returns copy of the stored records.
+func (r *RequestRecorder) Records() []RequestRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RequestRecord(nil), r.records...)
+}
+
+// WriteFile appends the records to the given file as JSON lines, so multiple test packages can record into the same file.
+func (r *RequestRecorder) WriteFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, rec := range r.Records() {
+		if err := enc.Encode(rec); err != nil {
+			return errors.Join(err, f.Close())
+		}
+	}
+	return f.Close()
+}
+
+// ReadRequestRecords reads the records written by RequestRecorder.WriteFile.
+func ReadRequestRecords(path string) ([]RequestRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []RequestRecord
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if len(strings.TrimSpace(s.Text())) == 0 {
+			continue
+		}
+
+		var rec RequestRecord
+		if err := json.Unmarshal(s.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("invalid request record %q: %w", s.Text(), err)
+		}
+		records = append(records, rec)
+	}
+	return records, s.Err()
+}
+
+// EndpointCoverage describes which responses of an OpenAPI operation are covered by golden assertions.
+// Status codes are listed as they are written in the spec, e.g. "200", "4XX" or "default".
+type EndpointCoverage struct {
+	Method  string
+	Path    string
+	Covered []string
+	Missing []string
+}
+
+// CoverageReport correlates recorded Request assertions with the operations of an OpenAPI spec.
+type CoverageReport struct {
+	Endpoints []EndpointCoverage
+	// Unknown contains records which don't match any operation or response of the spec.
+	Unknown []RequestRecord
+}
+
+type openAPISpec struct {
+	Paths map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+type openAPIOperation struct {
+	Responses map[string]yaml.Node `yaml:"responses"`
+}
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// APICoverage parses the OpenAPI spec in JSON or YAML format and reports which endpoints and status codes
+// have golden coverage based on the recorded Request assertions. The basePath is stripped from the recorded paths
+// before matching them against the spec paths, e.g. "/api/v1".
+func APICoverage(spec []byte, basePath string, records []RequestRecord) (*CoverageReport, error) {
+	var doc openAPISpec
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	type operation struct {
+		method, path string
+		codes        []string
+		covered      map[string]bool
+	}
+
+	var ops []*operation
+	for path, item := range doc.Paths {
+		for _, method := range httpMethods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+
+			var op openAPIOperation
+			if err := node.Decode(&op); err != nil {
+				return nil, fmt.Errorf("invalid operation %s %s: %w", strings.ToUpper(method), path, err)
+			}
+
+			codes := make([]string, 0, len(op.Responses))
+			for code := range op.Responses {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+			ops = append(ops, &operation{method: strings.ToUpper(method), path: path, codes: codes, covered: map[string]bool{}})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].path != ops[j].path {
+			return ops[i].path < ops[j].path
+		}
+		return ops[i].method < ops[j].method
+	})
+
+	report := &CoverageReport{}
+	for _, rec := range records {
+		path := "/" + strings.TrimPrefix(strings.TrimPrefix(rec.Path, strings.TrimSuffix(basePath, "/")), "/")
+
+		matched := false
+		for _, op := range ops {
+			if op.method != strings.ToUpper(rec.Method) || !matchPathTemplate(op.path, path) {
+				continue
+			}
+			if code, ok := matchStatusCode(op.codes, rec.StatusCode); ok {
+				op.covered[code] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			report.Unknown = append(report.Unknown, rec)
+		}
+	}
+
+	for _, op := range ops {
+		ec := EndpointCoverage{Method: op.method, Path: op.path}
+		for _, code := range op.codes {
+			if op.covered[code] {
+				ec.Covered = append(ec.Covered, code)
+			} else {
+				ec.Missing = append(ec.Missing, code)
+			}
+		}
+		report.Endpoints = append(report.Endpoints, ec)
+	}
+	return report, nil
+}
+
+// matchPathTemplate matches the path against OpenAPI path template like /users/{id}.
+func matchPathTemplate(template, path string) bool {
+	ts := strings.Split(strings.Trim(template, "/"), "/")
+	ps := strings.Split(strings.Trim(path, "/"), "/")
+	if len(ts) != len(ps) {
+		return false
+	}
+
+	for i := range ts {
+		if strings.HasPrefix(ts[i], "{") && strings.HasSuffix(ts[i], "}") && ps[i] != "" {
+			continue
+		}
+		if ts[i] != ps[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchStatusCode returns the most specific response code of the spec which matches the status code.
+func matchStatusCode(codes []string, status int) (string, bool) {
+	exact := strconv.Itoa(status)
+	wildcard := exact[:1] + "XX"
+	for _, candidate := range []string{exact, wildcard, "default"} {
+		for _, code := range codes {
+			if strings.EqualFold(code, candidate) {
+				return code, true
+			}
+		}
+	}
+	return "", false
+}
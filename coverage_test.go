@@ -0,0 +1,68 @@
+package golden_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestRecorder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	t.Cleanup(srv.Close)
+
+	recorder := &golden.RequestRecorder{}
+	fh := &golden.FileHandler{
+		FileName:        golden.TestNameToFilePath,
+		ShouldRecreate:  golden.ParseRecreateFromEnv,
+		Equal:           golden.EqualWithDiff,
+		RequestRecorder: recorder,
+	}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/users/1", nil)
+	require.NoError(t, err)
+	mt := &mockT{name: "TestRequest"}
+	fh.Request(mt, http.DefaultClient, req, http.StatusBadRequest)
+	assert.False(t, mt.failed)
+
+	expected := []golden.RequestRecord{{Test: "TestRequest", Method: http.MethodGet, Path: "/api/v1/users/1", StatusCode: http.StatusBadRequest}}
+	assert.Equal(t, expected, recorder.Records())
+
+	path := filepath.Join(t.TempDir(), "requests.jsonl")
+	require.NoError(t, recorder.WriteFile(path))
+	require.NoError(t, recorder.WriteFile(path))
+	records, err := golden.ReadRequestRecords(path)
+	require.NoError(t, err)
+	assert.Equal(t, append(expected, expected...), records)
+}
+
+func TestAPICoverage(t *testing.T) {
+	spec, err := os.ReadFile("testdata/openapi/openapi.yaml")
+	require.NoError(t, err)
+
+	report, err := golden.APICoverage(spec, "/api/v1", []golden.RequestRecord{
+		{Method: "GET", Path: "/api/v1/users", StatusCode: 200},
+		{Method: "POST", Path: "/api/v1/users", StatusCode: 422},
+		{Method: "GET", Path: "/api/v1/users/1", StatusCode: 500},
+		{Method: "DELETE", Path: "/api/v1/users/1", StatusCode: 204},
+		{Method: "GET", Path: "/api/v1/users/1/friends", StatusCode: 200},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []golden.EndpointCoverage{
+		{Method: "GET", Path: "/users", Covered: []string{"200"}},
+		{Method: "POST", Path: "/users", Covered: []string{"4XX"}, Missing: []string{"201"}},
+		{Method: "GET", Path: "/users/{id}", Covered: []string{"default"}, Missing: []string{"200", "404"}},
+	}, report.Endpoints)
+	assert.Equal(t, []golden.RequestRecord{
+		{Method: "DELETE", Path: "/api/v1/users/1", StatusCode: 204},
+		{Method: "GET", Path: "/api/v1/users/1/friends", StatusCode: 200},
+	}, report.Unknown)
+}
@@ -0,0 +1,77 @@
+package golden
+
+import (
+	"encoding/csv"
+	"sort"
+	"strings"
+)
+
+// CSVOptions configures the CSV normalization done by CSVProcessor.
+type CSVOptions struct {
+	// Comma is the field delimiter, defaults to ','. Use '\t' for TSV.
+	Comma rune
+	// Header marks the first record as header which is kept first when sorting.
+	Header bool
+	// SortBy lists the zero based key columns used for sorting the records, records are not sorted when empty.
+	SortBy []int
+}
+
+// PrettyCSV normalizes the CSV content: fields are trimmed from surrounding whitespace
+// and written back using minimal quoting with '\n' line endings.
+// This makes the golden files independent of the quoting and padding quirks of the CSV serializer.
+func PrettyCSV(t T, data string) string {
+	return CSVProcessor(CSVOptions{})(t, data)
+}
+
+// CSVProcessor returns ProcessContent function which normalizes the CSV content like PrettyCSV
+// and optionally sorts the records by the key columns.
+func CSVProcessor(opts CSVOptions) func(T, string) string {
+	return func(t T, data string) string {
+		comma := opts.Comma
+		if comma == 0 {
+			comma = ','
+		}
+
+		r := csv.NewReader(strings.NewReader(data))
+		r.Comma = comma
+		r.FieldsPerRecord = -1
+		r.TrimLeadingSpace = true
+		records, err := r.ReadAll()
+		NoError(t, err, "failed to parse CSV")
+
+		for _, record := range records {
+			for i := range record {
+				record[i] = strings.TrimSpace(record[i])
+			}
+		}
+
+		if len(opts.SortBy) > 0 {
+			body := records
+			if opts.Header && len(records) > 0 {
+				body = records[1:]
+			}
+			sort.SliceStable(body, func(i, j int) bool {
+				for _, col := range opts.SortBy {
+					a, b := field(body[i], col), field(body[j], col)
+					if a != b {
+						return a < b
+					}
+				}
+				return false
+			})
+		}
+
+		sb := &strings.Builder{}
+		w := csv.NewWriter(sb)
+		w.Comma = comma
+		NoError(t, w.WriteAll(records), "failed to write CSV")
+		return sb.String()
+	}
+}
+
+func field(record []string, i int) string {
+	if i < len(record) {
+		return record[i]
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrettyCSV(t *testing.T) {
+	mt := &mockT{name: "TestPrettyCSV"}
+	got := golden.PrettyCSV(mt, "\"id\", \"name\",note\r\n\"1\",  someone , \"has, comma\"\r\n2,\"other\",\"\"\"quoted\"\"\"\r\n")
+	assert.Equal(t, "id,name,note\n1,someone,\"has, comma\"\n2,other,\"\"\"quoted\"\"\"\n", got)
+	assert.False(t, mt.failed)
+}
+
+func TestCSVProcessor_Sort(t *testing.T) {
+	process := golden.CSVProcessor(golden.CSVOptions{Comma: '\t', Header: true, SortBy: []int{1, 0}})
+	mt := &mockT{name: "TestCSVProcessor"}
+	got := process(mt, "id\tgroup\n3\tb\n2\ta\n1\tb\n")
+	assert.Equal(t, "id\tgroup\n2\ta\n1\tb\n3\tb\n", got)
+	assert.False(t, mt.failed)
+}
+
+func TestPrettyCSV_Invalid(t *testing.T) {
+	mt := &mockT{name: "TestPrettyCSV"}
+	golden.PrettyCSV(mt, "a,\"b\nc")
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "failed to parse CSV")
+}
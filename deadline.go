@@ -0,0 +1,129 @@
+package golden
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// compare runs the comparison, in a goroutine which is abandoned when less than DeadlineMargin remains before
+// the test deadline, the partial report of the first difference is returned as the diff then.
+// The abandoned goroutine can outlive the test, so it compares copies of the content, the golden file content
+// can be memory-mapped and the mapping is released when the test finishes, see MmapThreshold.
+func (h *FileHandler) compare(t T, fileName, expected, actual string, fn func(t T, expected, actual string) (bool, string)) (bool, string) {
+	t.Helper()
+	dt, ok := t.(interface{ Deadline() (time.Time, bool) })
+	if h.DeadlineMargin <= 0 || !ok || expected == actual {
+		return fn(t, expected, actual)
+	}
+	deadline, ok := dt.Deadline()
+	if !ok {
+		return fn(t, expected, actual)
+	}
+
+	type result struct {
+		matched bool
+		diff    string
+	}
+	rt := &recordingT{T: t}
+	done := make(chan result, 1)
+	expectedCopy, actualCopy := strings.Clone(expected), strings.Clone(actual)
+	go func() {
+		matched, diff := fn(rt, expectedCopy, actualCopy)
+		done <- result{matched: matched, diff: diff}
+	}()
+
+	timer := time.NewTimer(time.Until(deadline) - h.DeadlineMargin)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		rt.replay(t)
+		return r.matched, r.diff
+	case <-timer.C:
+		rt.abandon()
+		diff := PartialDiff(fileName, "actual", expected, actual)
+		t.Errorf("comparison with golden file %s was aborted, less than %s remained before the test deadline\n%s",
+			fileName, h.DeadlineMargin, diff)
+		return false, diff
+	}
+}
+
+// PartialDiff describes the difference between the expected and actual content by their sizes and the first
+// differing line, it's used instead of the full diff when the comparison is aborted, see DeadlineMargin.
+func PartialDiff(expectedName, actualName, expected, actual string) string {
+	const maxLineLen = 200
+	truncate := func(line string) string {
+		if len(line) > maxLineLen {
+			return line[:maxLineLen] + "..."
+		}
+		return line
+	}
+
+	expectedLines, actualLines := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "--- %s (%d bytes, %d lines)\n+++ %s (%d bytes, %d lines)\n",
+		expectedName, len(expected), len(expectedLines), actualName, len(actual), len(actualLines))
+	for i := 0; i < max(len(expectedLines), len(actualLines)); i++ {
+		if i < len(expectedLines) && i < len(actualLines) && expectedLines[i] == actualLines[i] {
+			continue
+		}
+		fmt.Fprintf(sb, "first difference at line %d:\n", i+1)
+		if i < len(expectedLines) {
+			fmt.Fprintf(sb, "-%s\n", truncate(expectedLines[i]))
+		}
+		if i < len(actualLines) {
+			fmt.Fprintf(sb, "+%s\n", truncate(actualLines[i]))
+		}
+		break
+	}
+	return sb.String()
+}
+
+// recordingT records the failures and logs for replaying them on the test, which is done only when the comparison
+// finishes in time, since the test can't be used after it completed.
+type recordingT struct {
+	T
+	mu        sync.Mutex
+	abandoned bool
+	calls     []func(T)
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Logf(format string, args ...any) {
+	r.record(func(t T) { t.Logf(format, args...) })
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.record(func(t T) { t.Errorf(format, args...) })
+}
+
+func (r *recordingT) FailNow() {
+	r.record(func(t T) { t.FailNow() })
+}
+
+func (r *recordingT) record(call func(T)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.abandoned {
+		r.calls = append(r.calls, call)
+	}
+}
+
+func (r *recordingT) replay(t T) {
+	t.Helper()
+	r.mu.Lock()
+	calls := r.calls
+	r.mu.Unlock()
+	for _, call := range calls {
+		call(t)
+	}
+}
+
+func (r *recordingT) abandon() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.abandoned = true
+	r.calls = nil
+}
@@ -0,0 +1,86 @@
+package golden_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deadlineT struct {
+	mockT
+	deadline time.Time
+}
+
+func (d *deadlineT) Deadline() (time.Time, bool) { return d.deadline, true }
+
+func TestDeadlineMargin(t *testing.T) {
+	t.Chdir(t.TempDir())
+	name := "testdata/TestDeadline/TestDeadline.golden"
+	require.NoError(t, golden.OSStorage{}.WriteFile(name, []byte("a\nb\nc\n")))
+
+	release := make(chan struct{})
+	defer close(release)
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal: func(t golden.T, expected, actual string, msgAndArgs ...interface{}) bool {
+			if strings.Contains(actual, "slow") {
+				<-release
+			}
+			return golden.EqualWithDiff(t, expected, actual, msgAndArgs...)
+		},
+		DeadlineMargin: time.Minute,
+	}
+
+	mt := &deadlineT{mockT: mockT{name: "TestDeadline"}, deadline: time.Now().Add(time.Minute + 50*time.Millisecond)}
+	res := fh.AssertResult(mt, "a\nslow\nc\n")
+	assert.True(t, mt.failed)
+	assert.False(t, res.Matched)
+	assert.Equal(t, "\ncomparison with golden file "+name+" was aborted, less than 1m0s remained before the test deadline\n"+
+		"--- "+name+" (6 bytes, 4 lines)\n+++ actual (9 bytes, 4 lines)\nfirst difference at line 2:\n-b\n+slow\n", mt.msg)
+
+	mt = &deadlineT{mockT: mockT{name: "TestDeadline"}, deadline: time.Now().Add(time.Hour)}
+	res = fh.AssertResult(mt, "a\nB\nc\n")
+	assert.True(t, mt.failed)
+	assert.False(t, res.Matched)
+	assert.Contains(t, mt.msg, "Not equal:", "failures of the finished comparison are reported")
+	assert.Contains(t, res.Diff, "-b\n+B\n")
+}
+
+type cleanupDeadlineT struct {
+	deadlineT
+	cleanups []func()
+}
+
+func (c *cleanupDeadlineT) Cleanup(fn func()) { c.cleanups = append(c.cleanups, fn) }
+
+func TestDeadlineMargin_Mapped(t *testing.T) {
+	t.Chdir(t.TempDir())
+	expected := strings.Repeat("line\n", 1000)
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestDeadline/TestDeadline.golden", []byte(expected)))
+
+	release, compared := make(chan struct{}), make(chan string)
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal: func(_ golden.T, expected, actual string, _ ...interface{}) bool {
+			<-release
+			compared <- strings.Clone(expected)
+			return expected == actual
+		},
+		DeadlineMargin: time.Minute,
+		MmapThreshold:  1,
+	}
+
+	mt := &cleanupDeadlineT{deadlineT: deadlineT{mockT: mockT{name: "TestDeadline"}, deadline: time.Now().Add(time.Minute + 50*time.Millisecond)}}
+	assert.False(t, fh.AssertResult(mt, "other").Matched)
+	require.Len(t, mt.cleanups, 1)
+	mt.cleanups[0]()
+
+	close(release)
+	assert.Equal(t, expected, <-compared, "the abandoned comparison doesn't read the released mapping")
+}
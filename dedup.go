@@ -0,0 +1,273 @@
+package golden
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// blobPointerPrefix starts the pointer files which DedupStorage stores in place of the content.
+const blobPointerPrefix = "golden-blob sha256:"
+
+// DedupStorage stores the content of the golden files once as content-addressed blobs and the golden files
+// as small pointer files referencing them, e.g. when dozens of subtests produce identical output.
+// The blobs are named by the SHA-256 hash of the content and stored in the .blobs directory of the nearest
+// testdata directory of the golden file, e.g. testdata/.blobs for testdata/TestX/TestX.golden.
+// The golden files which aren't pointers are read as is, so the existing golden files keep working.
+//
+// Recreating leaves the blobs which are no longer referenced behind, CompactBlobs deduplicates the identical
+// golden files written without DedupStorage and removes the unreferenced blobs when pruning:
+//
+//	go run github.com/go-tstr/golden/cmd/golden compact -prune
+type DedupStorage struct {
+	// Storage stores the files and the blobs, the OS filesystem is used when nil.
+	Storage Storage
+	// Dir overrides the blob directory.
+	Dir string
+	// MinSize is the size in bytes from which the content is stored as a blob, the smaller files are stored as is.
+	MinSize int
+}
+
+// ReadFile reads the file, or the blob it points to.
+func (s DedupStorage) ReadFile(name string) ([]byte, error) {
+	b, err := s.storage().ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	hash, ok := ParseBlobPointer(b)
+	if !ok {
+		return b, nil
+	}
+
+	blob, err := s.storage().ReadFile(filepath.Join(s.blobDir(name), hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob of golden file %s: %w", name, err)
+	}
+	if blobHash(blob) != hash {
+		return nil, fmt.Errorf("blob %s of golden file %s is corrupted", hash, name)
+	}
+	return blob, nil
+}
+
+// WriteFile writes the blob unless it exists and the pointer file referencing it.
+func (s DedupStorage) WriteFile(name string, data []byte) error {
+	if len(data) < s.MinSize {
+		return s.storage().WriteFile(name, data)
+	}
+
+	hash := blobHash(data)
+	blob := filepath.Join(s.blobDir(name), hash)
+	if err := statFile(s.storage(), blob); errors.Is(err, fs.ErrNotExist) {
+		if err := s.storage().WriteFile(blob, data); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	return s.storage().WriteFile(name, []byte(blobPointer(hash)))
+}
+
+// Stat returns the file info of the pointer file, or of the file stored as is, without reading the blob.
+func (s DedupStorage) Stat(name string) (fs.FileInfo, error) {
+	if st, ok := s.storage().(statStorage); ok {
+		return st.Stat(name)
+	}
+	b, err := s.storage().ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return storageFileInfo{name: filepath.Base(name), size: int64(len(b))}, nil
+}
+
+func (s DedupStorage) blobDir(name string) string {
+	if s.Dir != "" {
+		return s.Dir
+	}
+	return BlobDir(name)
+}
+
+func (s DedupStorage) storage() Storage {
+	if s.Storage == nil {
+		return OSStorage{}
+	}
+	return s.Storage
+}
+
+// BlobDir returns the directory of the blobs of the golden file, the .blobs directory of its nearest testdata directory,
+// or the .blobs directory next to the golden file when it isn't inside a testdata directory.
+func BlobDir(goldenFile string) string {
+	for dir := filepath.Dir(goldenFile); ; dir = filepath.Dir(dir) {
+		if filepath.Base(dir) == "testdata" {
+			return filepath.Join(dir, ".blobs")
+		}
+		if filepath.Dir(dir) == dir {
+			return filepath.Join(filepath.Dir(goldenFile), ".blobs")
+		}
+	}
+}
+
+// ParseBlobPointer returns the hash of the blob when the content is a pointer file written by DedupStorage.
+func ParseBlobPointer(data []byte) (string, bool) {
+	s, ok := strings.CutPrefix(string(data), blobPointerPrefix)
+	if !ok || len(data) > 128 {
+		return "", false
+	}
+	hash := strings.TrimSuffix(s, "\n")
+	return hash, isBlobHash(hash)
+}
+
+func isBlobHash(s string) bool {
+	return len(s) == sha256.Size*2 && strings.Trim(s, "0123456789abcdef") == ""
+}
+
+func blobPointer(hash string) string {
+	return blobPointerPrefix + hash + "\n"
+}
+
+func blobHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// CompactOptions configures CompactBlobs.
+type CompactOptions struct {
+	// Prune removes the blobs which no pointer file under the root directory references. The blob directories
+	// are pruned only when the walk covered their whole parent directory, so the blobs referenced from skipped
+	// hidden or vendor directories are kept. Pointer files outside the root directory referencing its blobs,
+	// e.g. through DedupStorage.Dir, aren't seen, so prune from the module root.
+	Prune bool
+}
+
+// CompactResult lists the changes done by CompactBlobs.
+type CompactResult struct {
+	// Deduplicated are the golden files replaced with pointer files.
+	Deduplicated []string
+	// Removed are the blobs which no pointer file referenced.
+	Removed []string
+}
+
+// CompactBlobs walks the root directory, replaces the golden files with identical content by pointer files
+// referencing a shared blob and, with CompactOptions.Prune, removes the blobs no pointer file references,
+// see DedupStorage. The golden files are deduplicated only within the same blob directory, see BlobDir.
+// All the regular files are checked for pointers, since DedupStorage can store any file.
+// Hidden directories and vendor directories are skipped.
+func CompactBlobs(root string, opts CompactOptions) (CompactResult, error) {
+	var res CompactResult
+	// identical golden files by blob directory and hash
+	identical := map[string]map[string][]string{}
+	referenced := map[string]bool{}
+	var blobDirs, skipped []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor") {
+				if d.Name() == ".blobs" {
+					blobDirs = append(blobDirs, path)
+				} else {
+					skipped = append(skipped, path)
+				}
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		golden := filepath.Ext(path) == ".golden"
+		if !golden {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if info.Size() > 128 {
+				return nil
+			}
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if hash, ok := ParseBlobPointer(b); ok {
+			referenced[hash] = true
+			return nil
+		}
+		if !golden {
+			return nil
+		}
+		dir := BlobDir(path)
+		if identical[dir] == nil {
+			identical[dir] = map[string][]string{}
+		}
+		hash := blobHash(b)
+		identical[dir][hash] = append(identical[dir][hash], path)
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	s := DedupStorage{}
+	for _, byHash := range identical {
+		for hash, files := range byHash {
+			if len(files) < 2 {
+				continue
+			}
+			for _, f := range files {
+				b, err := os.ReadFile(f)
+				if err != nil {
+					return res, err
+				}
+				if err := s.WriteFile(f, b); err != nil {
+					return res, err
+				}
+				res.Deduplicated = append(res.Deduplicated, f)
+			}
+			referenced[hash] = true
+		}
+	}
+
+	if opts.Prune {
+		for _, dir := range blobDirs {
+			if !walkCovered(filepath.Dir(dir), skipped) {
+				continue
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				return res, err
+			}
+			for _, e := range entries {
+				if e.IsDir() || !isBlobHash(e.Name()) || referenced[e.Name()] {
+					continue
+				}
+				blob := filepath.Join(dir, e.Name())
+				if err := os.Remove(blob); err != nil {
+					return res, err
+				}
+				res.Removed = append(res.Removed, blob)
+			}
+		}
+	}
+
+	sort.Strings(res.Deduplicated)
+	sort.Strings(res.Removed)
+	return res, nil
+}
+
+// walkCovered reports whether none of the skipped directories is inside the directory.
+func walkCovered(dir string, skipped []string) bool {
+	for _, s := range skipped {
+		if rel, err := filepath.Rel(dir, s); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return false
+		}
+	}
+	return true
+}
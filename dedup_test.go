@@ -0,0 +1,115 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupStorage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Storage:        golden.DedupStorage{MinSize: 5},
+	}
+
+	for _, name := range []string{"TestDedup/a", "TestDedup/b"} {
+		mt := &mockT{name: name}
+		assert.True(t, fh.Assert(mt, "identical output"))
+		assert.False(t, mt.failed, mt.msg)
+	}
+	mt := &mockT{name: "TestDedup/small"}
+	assert.True(t, fh.Assert(mt, "tiny"))
+
+	blobs, err := os.ReadDir("testdata/.blobs")
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+	hash := blobs[0].Name()
+	assertFileContent(t, "testdata/.blobs/"+hash, "identical output")
+	assertFileContent(t, "testdata/TestDedup/a.golden", "golden-blob sha256:"+hash+"\n")
+	assertFileContent(t, "testdata/TestDedup/b.golden", "golden-blob sha256:"+hash+"\n")
+	assertFileContent(t, "testdata/TestDedup/small.golden", "tiny")
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt = &mockT{name: "TestDedup/a"}
+	assert.False(t, fh.Assert(mt, "changed output"))
+	assert.Contains(t, mt.msg, "-identical output")
+
+	require.NoError(t, os.WriteFile("testdata/.blobs/"+hash, []byte("corrupted"), 0o600))
+	mt = &mockT{name: "TestDedup/b"}
+	fh.Assert(mt, "identical output")
+	assert.Contains(t, mt.msg, "blob "+hash+" of golden file testdata/TestDedup/b.golden is corrupted")
+}
+
+func TestCompactBlobs(t *testing.T) {
+	t.Chdir(t.TempDir())
+	s := golden.DedupStorage{}
+	require.NoError(t, s.WriteFile("testdata/TestA/pointer.golden", []byte("stored")))
+	require.NoError(t, s.WriteFile("testdata/TestA/stale.golden", []byte("stale")))
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestA/stale.golden", []byte("rewritten")))
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestA/a.golden", []byte("same")))
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestB/b.golden", []byte("same")))
+	require.NoError(t, golden.OSStorage{}.WriteFile("pkg/testdata/TestC/c.golden", []byte("same")))
+
+	res, err := golden.CompactBlobs(".", golden.CompactOptions{Prune: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"testdata/TestA/a.golden", "testdata/TestB/b.golden"}, res.Deduplicated,
+		"golden files of other testdata directories aren't deduplicated")
+	require.Len(t, res.Removed, 1)
+	assert.Equal(t, filepath.Join("testdata", ".blobs"), filepath.Dir(res.Removed[0]))
+
+	for f, want := range map[string]string{
+		"testdata/TestA/pointer.golden": "stored",
+		"testdata/TestA/stale.golden":   "rewritten",
+		"testdata/TestA/a.golden":       "same",
+		"testdata/TestB/b.golden":       "same",
+		"pkg/testdata/TestC/c.golden":   "same",
+	} {
+		b, err := s.ReadFile(f)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(b), f)
+	}
+	blobs, err := os.ReadDir("testdata/.blobs")
+	require.NoError(t, err)
+	assert.Len(t, blobs, 2)
+
+	res, err = golden.CompactBlobs(".", golden.CompactOptions{Prune: true})
+	require.NoError(t, err)
+	assert.Empty(t, res.Deduplicated)
+	assert.Empty(t, res.Removed)
+}
+
+func TestCompactBlobs_Prune(t *testing.T) {
+	t.Chdir(t.TempDir())
+	s := golden.DedupStorage{}
+	require.NoError(t, s.WriteFile("testdata/fixtures/invoice.json", []byte("invoice")))
+	require.NoError(t, s.WriteFile("testdata/TestA/stale.golden", []byte("stale")))
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestA/stale.golden", []byte("rewritten")))
+	require.NoError(t, s.WriteFile("pkg/testdata/.cache/hidden.golden", []byte("hidden")))
+	require.NoError(t, s.WriteFile("pkg/testdata/TestB/stale.golden", []byte("stale")))
+	require.NoError(t, golden.OSStorage{}.WriteFile("pkg/testdata/TestB/stale.golden", []byte("rewritten")))
+
+	res, err := golden.CompactBlobs(".", golden.CompactOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, res.Removed, "blobs are removed only when pruning")
+
+	res, err = golden.CompactBlobs(".", golden.CompactOptions{Prune: true})
+	require.NoError(t, err)
+	require.Len(t, res.Removed, 1, "blob directories with skipped subdirectories aren't pruned")
+	assert.Equal(t, filepath.Join("testdata", ".blobs"), filepath.Dir(res.Removed[0]))
+
+	for f, want := range map[string]string{
+		"testdata/fixtures/invoice.json":    "invoice",
+		"pkg/testdata/.cache/hidden.golden": "hidden",
+	} {
+		b, err := s.ReadFile(f)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(b), f)
+	}
+}
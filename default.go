@@ -0,0 +1,41 @@
+package golden
+
+import "sync/atomic"
+
+var defaultHandler atomic.Pointer[FileHandler]
+
+// disabledBuild is set when the tests are built with the golden_disabled build tag.
+var disabledBuild bool
+
+// Default returns the handler used by the package-level functions, the one set by SetDefault or DefaultHandler.
+// The handler is disabled when the tests are built with the golden_disabled build tag, see Disabled.
+func Default() *FileHandler {
+	h := defaultHandler.Load()
+	if h == nil {
+		h = DefaultHandler
+	}
+	if disabledBuild && !h.NoOp {
+		return h.Disabled()
+	}
+	return h
+}
+
+// SetDefault replaces the handler used by the package-level functions and returns the previous one,
+// nil restores DefaultHandler. It's safe to call concurrently with the assertions, unlike modifying the fields
+// of DefaultHandler, which races with the tests running in parallel. The handler must not be modified afterwards,
+// configure a copy instead:
+//
+//	h := *golden.Default()
+//	h.WriteDiff = true
+//	prev := golden.SetDefault(&h)
+//	t.Cleanup(func() { golden.SetDefault(prev) })
+//
+// The handler is global, so the tests calling SetDefault must not run in parallel with the tests using
+// the package-level functions, which would assert with the replaced handler.
+func SetDefault(h *FileHandler) *FileHandler {
+	prev := defaultHandler.Swap(h)
+	if prev == nil {
+		prev = DefaultHandler
+	}
+	return prev
+}
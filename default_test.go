@@ -0,0 +1,36 @@
+package golden_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetDefault replaces the handler of the package-level functions, which is safe only because the package tests
+// don't use t.Parallel.
+func TestSetDefault(t *testing.T) {
+	t.Chdir(t.TempDir())
+	assert.Same(t, golden.DefaultHandler, golden.Default())
+
+	prev := golden.SetDefault(golden.DefaultHandler.Disabled())
+	t.Cleanup(func() { golden.SetDefault(prev) })
+	assert.Same(t, golden.DefaultHandler, prev)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mt := &mockT{name: "TestSetDefault"}
+			assert.True(t, golden.Assert(mt, "data"), "missing golden file passes with the disabled handler")
+			assert.False(t, mt.failed)
+		}()
+	}
+	golden.SetDefault(golden.DefaultHandler.Disabled())
+	wg.Wait()
+
+	assert.True(t, golden.SetDefault(nil).NoOp)
+	assert.Same(t, golden.DefaultHandler, golden.Default())
+}
@@ -0,0 +1,145 @@
+package golden
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// UnifiedDiff returns unified diff between expected and actual content with three lines of context.
+// Empty string is returned when the contents are equal.
+func UnifiedDiff(expectedName, actualName, expected, actual string) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(expected),
+		B:        splitLines(actual),
+		FromFile: expectedName,
+		ToFile:   actualName,
+		Context:  3,
+	})
+	return diff
+}
+
+// splitLines splits the content into lines which all end with a newline as required by difflib.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
+
+// EqualWithTruncatedDiff returns Equal function which compares the strings and reports the mismatch as unified diff
+// truncated to maxLines lines, see TruncateDiff.
+func EqualWithTruncatedDiff(maxLines int) func(t T, expected, actual string, msgAndArgs ...interface{}) (ok bool) {
+	return func(t T, expected, actual string, msgAndArgs ...interface{}) (ok bool) {
+		t.Helper()
+		if expected == actual {
+			return true
+		}
+
+		diff, hunks, lines := TruncateDiff(UnifiedDiff("expected", "actual", expected, actual), maxLines)
+		msg := "Not equal:\n" + diff
+		if lines > 0 {
+			msg += "\n" + truncationNote(hunks, lines) + ", see MaxDiffLines"
+		}
+		if len(msgAndArgs) > 0 {
+			msg += "\n" + messageFromMsgAndArgs(msgAndArgs...)
+		}
+		t.Errorf("%s", msg)
+		return false
+	}
+}
+
+// TruncateDiff limits the unified diff to maxLines lines by keeping the whole hunks from its head and tail
+// and replacing the hunks in the middle with a note about the number of omitted hunks. File headers aren't counted.
+// When even the first hunk is longer than maxLines, its head is kept and the rest of the hunk is omitted.
+// The numbers of omitted hunks and lines are returned, zero lines means that the diff wasn't truncated.
+func TruncateDiff(diff string, maxLines int) (truncated string, omittedHunks, omittedLines int) {
+	lines := strings.SplitAfter(diff, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	start := 0
+	for start < len(lines) && !strings.HasPrefix(lines[start], "@@") {
+		start++
+	}
+	if maxLines <= 0 || len(lines)-start <= maxLines {
+		return diff, 0, 0
+	}
+
+	var hunks [][]string
+	for _, l := range lines[start:] {
+		if strings.HasPrefix(l, "@@") {
+			hunks = append(hunks, nil)
+		}
+		hunks[len(hunks)-1] = append(hunks[len(hunks)-1], l)
+	}
+
+	// the hunks are taken alternately from the head and the tail while they fit
+	head, tail, used := 0, len(hunks), 0
+	for fromHead := true; head < tail; fromHead = !fromHead {
+		next := head
+		if !fromHead {
+			next = tail - 1
+		}
+		if used+len(hunks[next]) > maxLines {
+			break
+		}
+		used += len(hunks[next])
+		if fromHead {
+			head++
+		} else {
+			tail--
+		}
+	}
+
+	sb := &strings.Builder{}
+	for _, l := range lines[:start] {
+		sb.WriteString(l)
+	}
+	if head == 0 {
+		// even the first hunk doesn't fit, so only its head is kept
+		kept := hunks[0][:max(maxLines, 1)]
+		for _, l := range kept {
+			sb.WriteString(l)
+		}
+		omittedLines = len(hunks[0]) - len(kept)
+		fmt.Fprintf(sb, "... %d lines of the hunk omitted ...\n", omittedLines)
+		head = 1
+	} else {
+		for _, h := range hunks[:head] {
+			for _, l := range h {
+				sb.WriteString(l)
+			}
+		}
+	}
+	if head < tail {
+		n := 0
+		for _, h := range hunks[head:tail] {
+			n += len(h)
+		}
+		omittedHunks, omittedLines = tail-head, omittedLines+n
+		fmt.Fprintf(sb, "... %d hunks (%d lines) omitted ...\n", omittedHunks, n)
+	}
+	for _, h := range hunks[tail:] {
+		for _, l := range h {
+			sb.WriteString(l)
+		}
+	}
+	return sb.String(), omittedHunks, omittedLines
+}
+
+// truncationNote describes the part of the diff omitted by TruncateDiff.
+func truncationNote(hunks, lines int) string {
+	if hunks == 0 {
+		return fmt.Sprintf("%d diff lines omitted", lines)
+	}
+	return fmt.Sprintf("%d diff hunks (%d lines) omitted", hunks, lines)
+}
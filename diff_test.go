@@ -0,0 +1,127 @@
+package golden_test
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDiff(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestWriteDiff"), "failed to remove testdata") })
+	require.NoError(t, os.MkdirAll("./testdata/TestWriteDiff", 0o755))
+	require.NoError(t, os.WriteFile("./testdata/TestWriteDiff/TestWriteDiff.golden", []byte("a\nb\nc\n"), 0o600))
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		WriteDiff:      true,
+	}
+
+	mt := &mockT{name: "TestWriteDiff"}
+	assert.False(t, fh.Assert(mt, "a\nx\nc\n"))
+	assert.Contains(t, mt.msg, "full diff written to testdata/TestWriteDiff/TestWriteDiff.golden.diff")
+
+	b, err := os.ReadFile("./testdata/TestWriteDiff/TestWriteDiff.golden.diff")
+	require.NoError(t, err)
+	assert.Equal(t, `--- testdata/TestWriteDiff/TestWriteDiff.golden
++++ actual
+@@ -1,3 +1,3 @@
+ a
+-b
++x
+ c
+`, string(b))
+
+	mt = &mockT{name: "TestWriteDiff"}
+	assert.True(t, fh.Assert(mt, "a\nb\nc\n"))
+	assert.NoFileExists(t, "./testdata/TestWriteDiff/TestWriteDiff.golden.diff", "stale diff is removed")
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	assert.Empty(t, golden.UnifiedDiff("a", "b", "same", "same"))
+}
+
+func TestMaxDiffLines(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestMaxDiffLines"), "failed to remove testdata") })
+	require.NoError(t, os.MkdirAll("./testdata/TestMaxDiffLines", 0o755))
+	require.NoError(t, os.WriteFile("./testdata/TestMaxDiffLines/TestMaxDiffLines.golden", []byte(strings.Repeat("a\n", 100)), 0o600))
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		WriteDiff:      true,
+		MaxDiffLines:   4,
+	}
+
+	mt := &mockT{name: "TestMaxDiffLines"}
+	assert.False(t, fh.Assert(mt, strings.Repeat("b\n", 100)))
+	assert.Equal(t, `
+Not equal:
+--- expected
++++ actual
+@@ -1,100 +1,100 @@
+-a
+-a
+-a
+... 197 lines of the hunk omitted ...
+
+197 diff lines omitted, see MaxDiffLines
+full diff written to testdata/TestMaxDiffLines/TestMaxDiffLines.golden.diff
+golden file: testdata/TestMaxDiffLines/TestMaxDiffLines.golden (200 bytes)
+update with: GOLDEN_FILES_RECREATE=true go test -run '^TestMaxDiffLines$' .`, mt.msg)
+}
+
+func TestTruncateDiff(t *testing.T) {
+	diff := golden.UnifiedDiff("a", "b", "1\n2\n3\n", "1\n4\n3\n")
+	got, hunks, lines := golden.TruncateDiff(diff, 10)
+	assert.Equal(t, diff, got)
+	assert.Zero(t, hunks)
+	assert.Zero(t, lines)
+
+	got, hunks, lines = golden.TruncateDiff(diff, 3)
+	assert.Equal(t, "--- a\n+++ b\n@@ -1,3 +1,3 @@\n 1\n-2\n... 2 lines of the hunk omitted ...\n", got)
+	assert.Zero(t, hunks)
+	assert.Equal(t, 2, lines)
+}
+
+func TestTruncateDiff_HunkBoundaries(t *testing.T) {
+	var expected, actual []string
+	for i := 1; i <= 40; i++ {
+		expected = append(expected, strconv.Itoa(i))
+		actual = append(actual, strconv.Itoa(i))
+	}
+	for _, i := range []int{2, 14, 26, 38} {
+		actual[i-1] = "changed"
+	}
+	diff := golden.UnifiedDiff("a", "b", strings.Join(expected, "\n")+"\n", strings.Join(actual, "\n")+"\n")
+
+	got, hunks, lines := golden.TruncateDiff(diff, 16)
+	assert.Equal(t, `--- a
++++ b
+@@ -1,5 +1,5 @@
+ 1
+-2
++changed
+ 3
+ 4
+ 5
+... 2 hunks (18 lines) omitted ...
+@@ -35,6 +35,6 @@
+ 35
+ 36
+ 37
+-38
++changed
+ 39
+ 40
+`, got)
+	assert.Equal(t, 2, hunks)
+	assert.Equal(t, 18, lines)
+}
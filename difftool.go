@@ -0,0 +1,45 @@
+package golden
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ParseDiffCommandFromEnv returns the command from GOLDEN_DIFF_CMD environment variable split by whitespace,
+// e.g. GOLDEN_DIFF_CMD="code --diff --wait" or GOLDEN_DIFF_CMD=delta.
+// The command is never launched in CI, which is detected from the CI environment variable.
+func ParseDiffCommandFromEnv(_ T) []string {
+	if os.Getenv("CI") != "" {
+		return nil
+	}
+	return strings.Fields(os.Getenv("GOLDEN_DIFF_CMD"))
+}
+
+// runDiffCommand writes the actual content into a temporary file and launches the diff command with the golden file
+// and the temporary file as the last arguments. The temporary file is left in place since GUI tools may open it asynchronously.
+func runDiffCommand(t T, command []string, fileName, actual string) {
+	f, err := os.CreateTemp("", filepath.Base(fileName)+".*.actual")
+	if err != nil {
+		t.Logf("failed to create file for diff command: %s", err)
+		return
+	}
+
+	_, err = f.WriteString(actual)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		t.Logf("failed to write file for diff command: %s", err)
+		return
+	}
+
+	args := append(append([]string{}, command[1:]...), fileName, f.Name())
+	cmd := exec.Command(command[0], args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		t.Logf("diff command %q failed: %s", strings.Join(cmd.Args, " "), err)
+	}
+}
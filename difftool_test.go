@@ -0,0 +1,45 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiffCommandFromEnv(t *testing.T) {
+	t.Setenv("CI", "")
+	t.Setenv("GOLDEN_DIFF_CMD", "code --diff  --wait")
+	assert.Equal(t, []string{"code", "--diff", "--wait"}, golden.ParseDiffCommandFromEnv(t))
+
+	t.Setenv("CI", "true")
+	assert.Nil(t, golden.ParseDiffCommandFromEnv(t))
+}
+
+func TestDiffCommand(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestDiffCommand"), "failed to remove testdata") })
+	require.NoError(t, os.MkdirAll("./testdata/TestDiffCommand", 0o755))
+	require.NoError(t, os.WriteFile("./testdata/TestDiffCommand/TestDiffCommand.golden", []byte("expected"), 0o600))
+
+	out := filepath.Join(t.TempDir(), "out")
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		DiffCommand: func(golden.T) []string {
+			return []string{"sh", "-c", `cat "$0" "$1" > ` + out}
+		},
+	}
+
+	mt := &mockT{name: "TestDiffCommand"}
+	assert.True(t, fh.Assert(mt, "expected"))
+	assert.NoFileExists(t, out, "diff command is launched only on failure")
+
+	assert.False(t, fh.Assert(mt, "actual"))
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "expectedactual", string(b))
+}
@@ -0,0 +1,24 @@
+package golden
+
+// Disabled returns a copy of the default handler, see Default, which passes all the assertions without reading
+// or writing the golden files, so benchmarks and fuzz targets reusing the test helpers don't pay for the file IO:
+//
+//	func BenchmarkRender(b *testing.B) {
+//		h := golden.Disabled()
+//		for b.Loop() {
+//			h.Assert(b, render())
+//		}
+//	}
+//
+// Building the tests with the golden_disabled build tag disables the default handler for the whole test binary,
+// including the handlers set with SetDefault.
+func Disabled() *FileHandler {
+	return Default().Disabled()
+}
+
+// Disabled returns a copy of the handler which passes all the assertions without reading or writing the golden files.
+func (h *FileHandler) Disabled() *FileHandler {
+	c := *h
+	c.NoOp = true
+	return &c
+}
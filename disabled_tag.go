@@ -0,0 +1,8 @@
+//go:build golden_disabled
+
+package golden
+
+func init() {
+	disabledBuild = true
+	DefaultHandler.NoOp = true
+}
@@ -0,0 +1,23 @@
+//go:build golden_disabled
+
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisabledBuildTag(t *testing.T) {
+	t.Chdir(t.TempDir())
+	h := *golden.DefaultHandler
+	h.NoOp = false
+	prev := golden.SetDefault(&h)
+	t.Cleanup(func() { golden.SetDefault(prev) })
+
+	mt := &mockT{name: "TestDisabledBuildTag"}
+	assert.True(t, golden.Assert(mt, "data"), "the handler set with SetDefault is disabled too")
+	assert.False(t, mt.failed)
+	assert.NoDirExists(t, "testdata")
+}
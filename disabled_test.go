@@ -0,0 +1,35 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisabled(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}).Disabled()
+
+	mt := &mockT{name: "TestDisabled"}
+	assert.True(t, fh.Assert(mt, "data"))
+	assert.True(t, fh.AssertValue(mt, map[string]int{"a": 1}))
+	want := "other"
+	assert.True(t, fh.AssertInline(mt, "data", &want))
+	assert.False(t, mt.failed)
+	assert.Empty(t, mt.logs)
+	_, err := os.Stat("testdata")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func BenchmarkDisabled(b *testing.B) {
+	h := golden.Disabled()
+	for b.Loop() {
+		h.Assert(b, "data")
+	}
+}
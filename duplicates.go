@@ -0,0 +1,106 @@
+package golden
+
+import (
+	"crypto/sha256"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Duplicates is a group of golden files with identical or near-identical content.
+type Duplicates struct {
+	// Files are the paths of the golden files in the group.
+	Files []string
+	// Similarity is 1 for identical files, otherwise it's the share of lines the files have in common.
+	Similarity float64
+}
+
+// FindDuplicates walks the root directory and finds golden files with identical content,
+// and pairs of golden files whose Similarity is at least minSimilarity.
+// Near-identical pairs are not searched when minSimilarity is 0 or greater than or equal to 1.
+// Hidden directories and vendor directories are skipped.
+func FindDuplicates(root string, minSimilarity float64) ([]Duplicates, error) {
+	byHash := map[[sha256.Size]byte][]string{}
+	contents := map[string]string{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".golden" {
+			return nil
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(b)
+		byHash[sum] = append(byHash[sum], path)
+		contents[path] = string(b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result  []Duplicates
+		uniques []string
+	)
+	for _, files := range byHash {
+		sort.Strings(files)
+		uniques = append(uniques, files[0])
+		if len(files) > 1 {
+			result = append(result, Duplicates{Files: files, Similarity: 1})
+		}
+	}
+
+	if minSimilarity > 0 && minSimilarity < 1 {
+		sort.Strings(uniques)
+		lines := make([][]string, len(uniques))
+		for i, f := range uniques {
+			lines[i] = strings.Split(contents[f], "\n")
+		}
+
+		for i := range uniques {
+			for j := i + 1; j < len(uniques); j++ {
+				if s := lineSimilarity(lines[i], lines[j]); s >= minSimilarity {
+					result = append(result, Duplicates{Files: []string{uniques[i], uniques[j]}, Similarity: s})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Similarity != result[j].Similarity {
+			return result[i].Similarity > result[j].Similarity
+		}
+		return result[i].Files[0] < result[j].Files[0]
+	})
+	return result, nil
+}
+
+// lineSimilarity returns the share of common lines of a and b, counting repeated lines as many times as they occur in both.
+func lineSimilarity(a, b []string) float64 {
+	counts := make(map[string]int, len(a))
+	for _, l := range a {
+		counts[l]++
+	}
+
+	common := 0
+	for _, l := range b {
+		if counts[l] > 0 {
+			counts[l]--
+			common++
+		}
+	}
+	return float64(2*common) / float64(len(a)+len(b))
+}
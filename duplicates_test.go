@@ -0,0 +1,46 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a/testdata/TestA/one.golden":     "same\ncontent\n",
+		"b/testdata/TestB/two.golden":     "same\ncontent\n",
+		"b/testdata/TestB/three.golden":   "line 1\nline 2\nline 3\nline 4\n",
+		"b/testdata/TestB/four.golden":    "line 1\nline 2\nline 3\nline 5\n",
+		"b/testdata/TestB/unique.golden":  "something else entirely",
+		"b/testdata/TestB/ignored.txt":    "same\ncontent\n",
+		".hidden/testdata/TestC/c.golden": "same\ncontent\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	groups, err := golden.FindDuplicates(dir, 0.7)
+	require.NoError(t, err)
+	assert.Equal(t, []golden.Duplicates{
+		{
+			Files:      []string{filepath.Join(dir, "a/testdata/TestA/one.golden"), filepath.Join(dir, "b/testdata/TestB/two.golden")},
+			Similarity: 1,
+		},
+		{
+			Files:      []string{filepath.Join(dir, "b/testdata/TestB/four.golden"), filepath.Join(dir, "b/testdata/TestB/three.golden")},
+			Similarity: 0.8,
+		},
+	}, groups)
+
+	groups, err = golden.FindDuplicates(dir, 1)
+	require.NoError(t, err)
+	assert.Len(t, groups, 1)
+}
@@ -0,0 +1,32 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailOnEmpty(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestEmpty"), "failed to remove testdata") })
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		FailOnEmpty:    true,
+	}
+
+	for _, data := range []string{"", " \n\t"} {
+		mt := &mockT{name: "TestEmpty"}
+		assert.False(t, fh.Assert(mt, data))
+		assert.True(t, mt.failed)
+		assert.Contains(t, mt.msg, "actual data is empty")
+		assert.NoDirExists(t, "./testdata/TestEmpty")
+	}
+
+	mt := &mockT{name: "TestEmpty"}
+	assert.True(t, fh.AllowEmpty().Assert(mt, ""))
+	assert.False(t, mt.failed)
+	assert.True(t, fh.FailOnEmpty)
+}
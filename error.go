@@ -0,0 +1,108 @@
+package golden
+
+import (
+	"errors"
+	"strings"
+)
+
+// NilError is used as the golden content when asserting nil error.
+const NilError = "<nil>"
+
+// AssertError checks the golden file content against err.Error(), nil error is rendered as NilError.
+func AssertError(t T, err error) bool {
+	return Default().AssertError(t, err)
+}
+
+// AssertErrorTree checks the golden file content against the error chain rendered by ErrorTree.
+func AssertErrorTree(t T, err error) bool {
+	return Default().AssertErrorTree(t, err)
+}
+
+func (h *FileHandler) AssertError(t T, err error) bool {
+	t.Helper()
+	if err == nil {
+		return h.Assert(t, NilError)
+	}
+	return h.Assert(t, err.Error())
+}
+
+func (h *FileHandler) AssertErrorTree(t T, err error) bool {
+	t.Helper()
+	return h.Assert(t, ErrorTree(err))
+}
+
+// ErrorTree renders the error and all the errors it wraps as an indented list, one list item per error.
+// Each item contains only the error's own message, without the messages of the errors it wraps.
+// Errors created with errors.Join or fmt.Errorf with multiple %w verbs have all their children rendered,
+// the errors.Join errors themselves have no message of their own, so their children take their place.
+// Nil error is rendered as NilError. For example:
+//
+//	parseErr := errors.Join(errors.New("line 1: unexpected token"), errors.New("line 2: unexpected token"))
+//	golden.ErrorTree(fmt.Errorf("open config: %w", fmt.Errorf("parse failed: %w", parseErr)))
+//	// - open config
+//	//   - parse failed
+//	//     - line 1: unexpected token
+//	//     - line 2: unexpected token
+func ErrorTree(err error) string {
+	if err == nil {
+		return NilError
+	}
+
+	sb := &strings.Builder{}
+	writeErrorTree(sb, err, 0)
+	return sb.String()
+}
+
+func writeErrorTree(sb *strings.Builder, err error, depth int) {
+	var children []error
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			if e != nil {
+				children = append(children, e)
+			}
+		}
+	default:
+		if e := errors.Unwrap(err); e != nil {
+			children = append(children, e)
+		}
+	}
+
+	msg := ownErrorMessage(err, children)
+	if msg == "" && len(children) > 0 {
+		for _, e := range children {
+			writeErrorTree(sb, e, depth)
+		}
+		return
+	}
+
+	indent := strings.Repeat("  ", depth)
+	sb.WriteString(indent + "- ")
+	sb.WriteString(strings.ReplaceAll(msg, "\n", "\n"+indent+"  "))
+	sb.WriteString("\n")
+	for _, e := range children {
+		writeErrorTree(sb, e, depth+1)
+	}
+}
+
+// ownErrorMessage returns the message of the error without the messages of the wrapped errors, which
+// fmt.Errorf appends after ": " and errors.Join joins with newlines. The whole message is returned when
+// the wrapped messages can't be stripped, e.g. with %w in the middle of the format.
+func ownErrorMessage(err error, children []error) string {
+	msg := err.Error()
+	if len(children) == 0 {
+		return msg
+	}
+	msgs := make([]string, 0, len(children))
+	for _, e := range children {
+		msgs = append(msgs, e.Error())
+	}
+	wrapped := strings.Join(msgs, "\n")
+	if msg == wrapped {
+		return ""
+	}
+	if own, ok := strings.CutSuffix(msg, ": "+wrapped); ok {
+		return own
+	}
+	return msg
+}
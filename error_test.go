@@ -0,0 +1,43 @@
+package golden_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertError(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestError"), "failed to remove testdata") })
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}
+
+	mt := &mockT{name: "TestError/nil"}
+	assert.True(t, fh.AssertError(mt, nil))
+	assert.FileExists(t, "./testdata/TestError/nil.golden")
+	b, err := os.ReadFile("./testdata/TestError/nil.golden")
+	assert.NoError(t, err)
+	assert.Equal(t, golden.NilError, string(b))
+
+	mt = &mockT{name: "TestError/error"}
+	assert.True(t, fh.AssertError(mt, fmt.Errorf("outer: %w", errors.New("inner"))))
+	b, err = os.ReadFile("./testdata/TestError/error.golden")
+	assert.NoError(t, err)
+	assert.Equal(t, "outer: inner", string(b))
+}
+
+func TestErrorTree(t *testing.T) {
+	base := errors.New("line 1: unexpected token")
+	joined := errors.Join(base, errors.New("line 2: unexpected token"))
+	err := fmt.Errorf("open config: %w", fmt.Errorf("parse failed: %w", joined))
+	golden.AssertErrorTree(t, err)
+	assert.Equal(t, golden.NilError, golden.ErrorTree(nil))
+	assert.Equal(t, "- read a and b\n  - a\n  - b\n",
+		golden.ErrorTree(fmt.Errorf("read %w and %w", errors.New("a"), errors.New("b"))), "message which can't be stripped is kept")
+}
@@ -0,0 +1,214 @@
+package golden
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// The extension interfaces below are the stable API for third-party plugins published as separate modules,
+// e.g. protobuf, parquet or image formats. The FileHandler fields ProcessContent and Equal accept plain functions,
+// the interfaces and their Func adapters make it possible to implement them as types with configuration.
+//
+// Format plugins are registered with RegisterContentType, typically in the init function of the plugin package:
+//
+//	func init() {
+//		golden.RegisterContentType(golden.ContentType{
+//			Name:           "protobuf",
+//			Sniff:          isProtobuf,
+//			ProcessContent: textproto.Process,
+//		})
+//	}
+//
+// The registered content types are included in DefaultContentTypes, so importing the plugin enables it:
+//
+//	import _ "example.com/golden-protobuf"
+//
+//	golden.DefaultHandler.ContentTypes = golden.DefaultContentTypes()
+
+// Processor transforms the actual content before it's stored and compared, see FileHandler.ProcessContent.
+type Processor interface {
+	Process(t T, data string) string
+}
+
+// ProcessorFunc is an adapter which allows using ordinary functions as Processor.
+type ProcessorFunc func(t T, data string) string
+
+// Process calls f(t, data).
+func (f ProcessorFunc) Process(t T, data string) string {
+	return f(t, data)
+}
+
+// Differ compares the golden file content against the actual content and reports the mismatch, see FileHandler.Equal.
+type Differ interface {
+	Equal(t T, expected, actual string, msgAndArgs ...interface{}) bool
+}
+
+// DifferFunc is an adapter which allows using ordinary functions as Differ.
+type DifferFunc func(t T, expected, actual string, msgAndArgs ...interface{}) bool
+
+// Equal calls f(t, expected, actual, msgAndArgs...).
+func (f DifferFunc) Equal(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+	return f(t, expected, actual, msgAndArgs...)
+}
+
+// DiffRenderer is implemented by the differs which also render the mismatch, see FileHandler.Diff.
+type DiffRenderer interface {
+	Diff(expectedName, actualName, expected, actual string) string
+}
+
+// Storage reads and writes the golden files, e.g. from an object store or an in-memory fixture.
+// The names are the ones returned by FileHandler.FileName. ReadFile must return an error wrapping
+// fs.ErrNotExist for missing files.
+type Storage interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte) error
+}
+
+// OSStorage stores the golden files in the OS filesystem, creating the missing directories when writing.
+// The permissions of the new directories are subject to the umask like with os.MkdirAll.
+type OSStorage struct {
+	// FileMode is the permission of the written files, 0600 subject to the umask when zero. When it's set,
+	// the written files are changed to it with os.Chmod, regardless of the umask and their previous permissions,
+	// so symlinked and hard linked golden files stay linked. Otherwise the permissions of existing files are kept.
+	FileMode fs.FileMode
+	// DirMode is the permission of the created directories, 0755 when zero.
+	DirMode fs.FileMode
+}
+
+// ReadFile reads the file using os.ReadFile.
+func (OSStorage) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// WriteFile creates the parent directories and writes the file.
+func (s OSStorage) WriteFile(name string, data []byte) error {
+	dirMode := s.DirMode
+	if dirMode == 0 {
+		dirMode = 0o755
+	}
+	if err := os.MkdirAll(filepath.Dir(name), dirMode); err != nil {
+		return err
+	}
+
+	if s.FileMode == 0 {
+		return os.WriteFile(name, data, 0o600)
+	}
+	if err := os.WriteFile(name, data, s.FileMode); err != nil {
+		return err
+	}
+	// os.WriteFile keeps the permissions of existing files and applies the umask to the new ones.
+	if info, err := os.Stat(name); err != nil || info.Mode().Perm() != s.FileMode.Perm() {
+		return os.Chmod(name, s.FileMode)
+	}
+	return nil
+}
+
+// Stat returns the file info using os.Stat.
+func (OSStorage) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// statStorage is implemented by the storages which can check the file without reading it, e.g. OSStorage.
+type statStorage interface {
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// storageFileInfo is the file info returned by the storages which don't store the files in the OS filesystem.
+type storageFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (fi storageFileInfo) Name() string       { return fi.name }
+func (fi storageFileInfo) Size() int64        { return fi.size }
+func (fi storageFileInfo) Mode() fs.FileMode  { return 0o444 }
+func (fi storageFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi storageFileInfo) IsDir() bool        { return false }
+func (fi storageFileInfo) Sys() any           { return nil }
+
+// fileExists reports whether the file exists in the storage, using Stat when the storage implements it.
+// Errors other than fs.ErrNotExist count as existing, so they are reported when the file is read.
+func fileExists(s Storage, name string) bool {
+	return !errors.Is(statFile(s, name), fs.ErrNotExist)
+}
+
+// statFile checks the file with Stat when the storage implements it, otherwise by reading it.
+func statFile(s Storage, name string) error {
+	if st, ok := s.(statStorage); ok {
+		_, err := st.Stat(name)
+		return err
+	}
+	_, err := s.ReadFile(name)
+	return err
+}
+
+// Remove removes the file using os.Remove.
+func (OSStorage) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// Recorder is notified about the result of each Assert, e.g. for reporting or metrics.
+// Implementations must be safe for concurrent use since parallel tests share the handler.
+type Recorder interface {
+	RecordResult(test string, res Result)
+}
+
+// WithProcessor returns a copy of the handler which processes the actual content with the processor.
+func (h *FileHandler) WithProcessor(p Processor) *FileHandler {
+	c := *h
+	c.ProcessContent = p.Process
+	return &c
+}
+
+// WithDiffer returns a copy of the handler which compares the content with the differ.
+// The differ also renders Result.Diff and the WriteDiff file when it's a DiffRenderer.
+func (h *FileHandler) WithDiffer(d Differ) *FileHandler {
+	c := *h
+	c.Equal = d.Equal
+	if r, ok := d.(DiffRenderer); ok {
+		c.Diff = r.Diff
+	}
+	return &c
+}
+
+func (h *FileHandler) storage() Storage {
+	if h.Storage == nil {
+		return OSStorage{FileMode: h.FileMode, DirMode: h.DirMode}
+	}
+	return h.Storage
+}
+
+var registered = struct {
+	mu    sync.RWMutex
+	types []ContentType
+}{}
+
+// RegisterContentType registers a content type plugin which is included in DefaultContentTypes.
+// The registered content types are sniffed before the built-in ones in the registration order,
+// so plugins can claim content which would otherwise be detected as binary or JSON.
+func RegisterContentType(ct ContentType) {
+	registered.mu.Lock()
+	defer registered.mu.Unlock()
+	registered.types = append(registered.types, ct)
+}
+
+// UnregisterContentType removes the content types registered with RegisterContentType under the name,
+// e.g. in the cleanup of a test registering a content type.
+func UnregisterContentType(name string) {
+	registered.mu.Lock()
+	defer registered.mu.Unlock()
+	registered.types = slices.DeleteFunc(registered.types, func(ct ContentType) bool { return ct.Name == name })
+}
+
+// RegisteredContentTypes returns the content types registered with RegisterContentType.
+func RegisteredContentTypes() []ContentType {
+	registered.mu.RLock()
+	defer registered.mu.RUnlock()
+	return append([]ContentType(nil), registered.types...)
+}
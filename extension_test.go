@@ -0,0 +1,100 @@
+package golden_test
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memStorage struct {
+	mu    sync.Mutex
+	files map[string]string
+}
+
+func (s *memStorage) ReadFile(name string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[name]
+	if !ok {
+		return nil, fmt.Errorf("read %s: %w", name, fs.ErrNotExist)
+	}
+	return []byte(data), nil
+}
+
+func (s *memStorage) WriteFile(name string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[name] = string(data)
+	return nil
+}
+
+type resultRecorder struct {
+	results map[string]golden.Result
+}
+
+func (r *resultRecorder) RecordResult(test string, res golden.Result) {
+	r.results[test] = res
+}
+
+type upperProcessor struct{}
+
+func (upperProcessor) Process(_ golden.T, data string) string { return strings.ToUpper(data) }
+
+func TestExtensions(t *testing.T) {
+	storage := &memStorage{files: map[string]string{}}
+	recorder := &resultRecorder{results: map[string]golden.Result{}}
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Storage:        storage,
+		Recorders:      []golden.Recorder{recorder},
+	}).WithProcessor(upperProcessor{}).WithDiffer(golden.DifferFunc(golden.EqualWithDiff))
+
+	assert.True(t, fh.Assert(&mockT{name: "TestMem"}, "data"))
+	assert.Equal(t, map[string]string{"testdata/TestMem/TestMem.golden": "DATA"}, storage.files)
+	assert.NoDirExists(t, "testdata/TestMem")
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt := &mockT{name: "TestMem"}
+	assert.False(t, fh.Assert(mt, "other"))
+	assert.Contains(t, mt.msg, "-DATA\n")
+	assert.False(t, recorder.results["TestMem"].Matched)
+
+	fh.MissingFile = golden.MissingFileCreate
+	assert.True(t, fh.Assert(&mockT{name: "TestMem/new"}, "new"))
+	assert.Equal(t, "NEW", storage.files["testdata/TestMem/new.golden"])
+	assert.True(t, recorder.results["TestMem/new"].WasRecreated)
+}
+
+func TestRegisterContentType(t *testing.T) {
+	golden.RegisterContentType(golden.ContentType{
+		Name:           "registered-test",
+		Sniff:          func(data []byte) bool { return strings.HasPrefix(string(data), "\x00REG") },
+		ProcessContent: func(_ golden.T, data string) string { return strings.TrimPrefix(data, "\x00REG") },
+	})
+	t.Cleanup(func() { golden.UnregisterContentType("registered-test") })
+
+	ct, ok := golden.DefaultContentTypes().Lookup([]byte("\x00REG payload"))
+	require.True(t, ok)
+	assert.Equal(t, "registered-test", ct.Name, "registered content types take precedence over binary")
+	registered := golden.RegisteredContentTypes()
+	assert.Equal(t, "registered-test", registered[len(registered)-1].Name)
+}
+
+func TestUnregisterContentType(t *testing.T) {
+	golden.RegisterContentType(golden.ContentType{Name: "unregistered-test", Sniff: func([]byte) bool { return true }})
+	golden.UnregisterContentType("unregistered-test")
+
+	for _, ct := range golden.RegisteredContentTypes() {
+		assert.NotEqual(t, "unregistered-test", ct.Name)
+	}
+	ct, ok := golden.DefaultContentTypes().Lookup([]byte("{}"))
+	require.True(t, ok)
+	assert.Equal(t, "json", ct.Name)
+}
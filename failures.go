@@ -0,0 +1,77 @@
+package golden
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Failure is an entry of the failure index written by FailureCollector.
+type Failure struct {
+	Test       string `json:"test"`
+	GoldenFile string `json:"golden_file"`
+	// DiffFile is the diff artifact of the failure, see FileHandler.WriteDiff. Empty when none was written.
+	DiffFile string `json:"diff_file,omitempty"`
+}
+
+// FailureCollector gathers the failed golden assertions across all the tests of the run, including parallel tests,
+// and writes them into a single index for batch triage tooling. FailureCollector is safe for concurrent use
+// and it's intended to be set up in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		failures := &golden.FailureCollector{}
+//		golden.DefaultHandler.Failures = failures
+//		golden.DefaultHandler.WriteDiff = true
+//
+//		code := m.Run()
+//		if err := failures.WriteFile("golden-failures.json"); err != nil {
+//			log.Fatal(err)
+//		}
+//		os.Exit(code)
+//	}
+type FailureCollector struct {
+	mu       sync.Mutex
+	failures []Failure
+}
+
+// Record stores the failure.
+func (c *FailureCollector) Record(f Failure) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, f)
+}
+
+// Failures returns the stored failures sorted by test name and golden file.
+func (c *FailureCollector) Failures() []Failure {
+	c.mu.Lock()
+	failures := append([]Failure(nil), c.failures...)
+	c.mu.Unlock()
+
+	sort.SliceStable(failures, func(i, j int) bool {
+		if failures[i].Test != failures[j].Test {
+			return failures[i].Test < failures[j].Test
+		}
+		return failures[i].GoldenFile < failures[j].GoldenFile
+	})
+	return failures
+}
+
+// WriteFile writes the failures as indented JSON array, the file is written even when there are no failures
+// so that stale indexes of previous runs don't mislead the tooling.
+func (c *FailureCollector) WriteFile(path string) error {
+	failures := c.Failures()
+	if failures == nil {
+		failures = []Failure{}
+	}
+
+	b, err := json.MarshalIndent(failures, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o600)
+}
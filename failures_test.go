@@ -0,0 +1,58 @@
+package golden_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureCollector(t *testing.T) {
+	t.Chdir(t.TempDir())
+	failures := &golden.FailureCollector{}
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		WriteDiff:      true,
+		Failures:       failures,
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fh := *fh
+			name := fmt.Sprintf("TestCollected/%d", i)
+			fh.Assert(&mockT{name: name}, "expected")
+			fh.ShouldRecreate = func(golden.T) bool { return false }
+			fh.Assert(&mockT{name: name}, "expected")
+			if i > 0 {
+				fh.Assert(&mockT{name: name}, "actual")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []golden.Failure{
+		{Test: "TestCollected/1", GoldenFile: "testdata/TestCollected/1.golden", DiffFile: "testdata/TestCollected/1.golden.diff"},
+		{Test: "TestCollected/2", GoldenFile: "testdata/TestCollected/2.golden", DiffFile: "testdata/TestCollected/2.golden.diff"},
+	}, failures.Failures())
+
+	path := filepath.Join("out", "failures.json")
+	require.NoError(t, failures.WriteFile(path))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"diff_file": "testdata/TestCollected/1.golden.diff"`)
+
+	require.NoError(t, (&golden.FailureCollector{}).WriteFile(path))
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", string(b))
+}
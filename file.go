@@ -3,23 +3,31 @@ package golden
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/tidwall/pretty"
 )
 
+// DefaultHandler is the initial handler of the package-level functions. Modifying it is safe only before the tests
+// start running, e.g. in TestMain or init, use SetDefault to replace the handler of the package-level functions.
 var DefaultHandler = &FileHandler{
 	FileName:       TestNameToFilePath,
 	ShouldRecreate: ParseRecreateFromEnv,
 	Equal:          EqualWithDiff,
 	ProcessContent: nil,
+	DiffCommand:    ParseDiffCommandFromEnv,
 }
 
 type FileHandler struct {
@@ -27,8 +35,202 @@ type FileHandler struct {
 	ShouldRecreate func(T) bool
 	ProcessContent func(T, string) string
 	Equal          func(t T, expected, actual string, msgAndArgs ...interface{}) (ok bool)
+
+	// NoOp makes the assertions pass without reading or writing the golden files, see Disabled.
+	NoOp bool
+
+	// FileMode and DirMode are the permissions of the golden files and their directories written when recreating,
+	// 0600 and 0755 when zero, see OSStorage. They aren't used when Storage is set.
+	FileMode fs.FileMode
+	DirMode  fs.FileMode
+
+	// WritePending writes the recreated content into {goldenFile}.pending instead of overwriting the golden file,
+	// so that regenerating and accepting the golden files are separate steps, see Approve.
+	// Approve renames the files in the OS filesystem, so it can't be used together with Storage.
+	WritePending bool
+
+	// ProtectedFiles are the globs of the golden files which must not be recreated, see Protected.
+	ProtectedFiles []string
+
+	// FailOnRecreate fails the assertions which would write the golden files, i.e. when recreating is requested
+	// by ShouldRecreate or the golden file doesn't exist, see ReadOnly.
+	FailOnRecreate bool
+
+	// OnRecreate is called before the golden file is written when recreating, e.g. for auditing or recording metrics.
+	// Old is nil when the golden file doesn't exist yet. The hook refuses the recreation by failing the test
+	// with t.Errorf or t.FailNow, the golden file isn't written then.
+	OnRecreate func(t T, path string, old, new []byte)
+
+	// Storage reads and writes the golden files, the OS filesystem is used when nil. See Storage.
+	Storage Storage
+
+	// FS is used for reading the golden files instead of the OS filesystem when set, e.g. embed.FS.
+	// File names returned by FileName are converted to slash separated paths relative to the FS root.
+	// Golden files are still written to and read from the disk when recreating.
+	FS fs.FS
+
+	// MissingFile selects what happens when the golden file doesn't exist and it isn't being recreated,
+	// by default the assertion fails.
+	MissingFile MissingFileBehavior
+
+	// FailOnEmpty makes Assert fail immediately when the actual data is empty or contains only whitespace.
+	// Empty output usually means that the code under test failed silently and recreating would produce a blank golden file.
+	// Use AllowEmpty for the assertions where empty output is expected.
+	FailOnEmpty bool
+
+	// Migrate upgrades the golden file content written in a legacy format, e.g. with an old scrubbing scheme, when it's read.
+	// It returns the upgraded content and true when the content was migrated, the comparison uses the upgraded content.
+	Migrate func(old string) (string, bool)
+	// RewriteMigrated writes the upgraded content back into the golden file, so the migration is done only once.
+	RewriteMigrated bool
+
+	// IgnoreLineMarker makes the golden file lines containing the marker match any actual line at the same position,
+	// e.g. IgnoreLine or a comment like "# golden:ignore", for unstable lines in otherwise stable output.
+	// The marked lines are kept when recreating, as long as the number of lines doesn't change.
+	IgnoreLineMarker string
+
+	// TemplateData renders the golden files as text/template with the values before the comparison, see WithTemplateData.
+	TemplateData map[string]any
+
+	// Tracker records the golden files asserted during the run and guards against accidental golden file creation.
+	Tracker *Tracker
+
+	// ProcessResponses selects ProcessContent in Request and Handler based on the response Content-Type:
+	// JSON is formatted with PrettyJSON, XML with PrettyXML and text is compared as is.
+	// Handler's own ProcessContent is used for the other content types.
+	ProcessResponses bool
+	// ResponseProcessors overrides the ProcessContent used in Request and Handler per media type, e.g. "text/csv".
+	// The overrides are used even when ProcessResponses isn't set, nil value disables processing for the media type.
+	ResponseProcessors map[string]func(T, string) string
+
+	// Retry retries Request, GraphQL, RequestSSE and RequestStream on transient errors,
+	// e.g. connection refused while the service under test is starting.
+	Retry RetryPolicy
+
+	// RecordRedirects includes the redirect chain followed by the client in the golden file of Request, see RedirectTranscript.
+	RecordRedirects bool
+
+	// RecordHeaders includes the response headers rendered with RenderHeaders in the golden file of Request when set.
+	RecordHeaders *HeaderOptions
+
+	// RecordCookies includes the cookies set by the response in the golden file of Request, see CookieTranscript.
+	RecordCookies bool
+	// ScrubCookieValues replaces the cookie values and expiration times with placeholders, e.g. for session IDs.
+	ScrubCookieValues bool
+
+	// MaxBodySize fails Request and Handler when the response body is larger than the given number of bytes, zero means no limit.
+	// It prevents writing a huge golden file accidentally, e.g. when an endpoint starts returning a file download.
+	MaxBodySize int64
+
+	// LiveRecorder records the Request assertions as live-verifiable, see Live.
+	LiveRecorder *LiveRecorder
+
+	// KeepGraphQLExtensions keeps the top level extensions of the GraphQL responses, see GraphQL.
+	KeepGraphQLExtensions bool
+
+	// RequestRecorder records the Request assertions for reporting API coverage, see APICoverage.
+	RequestRecorder *RequestRecorder
+
+	// Image configures the tolerances of AssertImage, the images must be pixel perfect match by default.
+	Image ImageOptions
+
+	// Audio configures the fingerprints of AssertAudio.
+	Audio AudioOptions
+
+	// Layout configures the rounding of the layout snapshots of AssertLayout.
+	Layout LayoutOptions
+
+	// Archive configures the rendering of the archives of AssertArchive.
+	Archive ArchiveOptions
+
+	// RenderMarkdown renders the Markdown of AssertMarkdown as HTML, see WithMarkdownRenderer.
+	RenderMarkdown func(src []byte) ([]byte, error)
+
+	// ContentTypes selects ProcessContent and Equal per assertion based on the actual data when set.
+	// The ones of the matching content type take precedence over the handler's own ProcessContent and Equal.
+	ContentTypes *ContentTypes
+
+	// WriteDiff writes the full unified diff into {goldenFile}.diff on mismatch and mentions its path in the failure message.
+	// Terminal output of large diffs is often truncated, the file can be also uploaded as CI artifact.
+	// Stale diff file is removed when the assertion passes.
+	WriteDiff bool
+
+	// Diff renders the mismatch for Result.Diff and the WriteDiff file, UnifiedDiff is used when nil.
+	// WithDiffer sets it when the differ is also a DiffRenderer, e.g. MoveDiffer.
+	Diff func(expectedName, actualName, expected, actual string) string
+
+	// MaxDiffLines limits the diff printed on mismatch to the given number of lines, the whole hunks from the head and tail
+	// of the diff are kept and the omitted hunks are counted, see TruncateDiff.
+	// When set, EqualWithTruncatedDiff is used instead of Equal, content type specific Equal still takes precedence.
+	MaxDiffLines int
+
+	// DeadlineMargin aborts the comparison when less than the margin remains before the test deadline and reports
+	// the first difference instead of the full diff, so huge comparisons don't run past -timeout.
+	// It requires T to implement Deadline() (time.Time, bool) like *testing.T does, see PartialDiff.
+	DeadlineMargin time.Duration
+
+	// Scrubbers replace unstable values with placeholders after ProcessContent, see Scrub.
+	Scrubbers []Scrubber
+	// Placeholder is the style of the placeholders rendered by Scrubbers, defaults to AngleBrackets.
+	Placeholder PlaceholderStyle
+
+	// HashOnly stores only the SHA-256 hash and the size of the content in the golden file and compares them,
+	// for huge outputs which are impractical to store, see ContentHash.
+	HashOnly bool
+	// DumpActual writes the actual content into a temporary file on hash-only mismatch and mentions its path in the failure message.
+	DumpActual bool
+
+	// HexDumpBinary compares the content with EqualHexDump when the golden file or the actual content contains
+	// non-printable bytes, so the diffs of binary content are rendered as reviewable hex dumps, see HasNonPrintable.
+	// The golden files still store the raw bytes.
+	HexDumpBinary bool
+
+	// ValidateUTF8 fails the assertion with the offset of the first invalid byte when the actual content
+	// or the golden file isn't valid UTF-8, e.g. when binary data is accidentally passed as string.
+	ValidateUTF8 bool
+	// BOM selects how the UTF-8 byte order mark inserted by some editors is handled, the content is kept as is by default.
+	BOM BOMPolicy
+
+	// MmapThreshold memory-maps the golden files of at least the given number of bytes instead of reading them,
+	// which avoids copying hundreds of megabytes into the heap. The mapping is released in t.Cleanup, so T must
+	// implement Cleanup(func()) like *testing.T does, see readGolden for the cases falling back to reading.
+	MmapThreshold int64
+
+	// DiffCommand returns the command which is launched with the golden file and actual content file paths
+	// as the last two arguments when the assertion fails, e.g. "code --diff". Nothing is launched when it returns nil.
+	DiffCommand func(T) []string
+
+	// MarshalValue serializes the values of AssertValue, indented JSON is used when nil, see SpewValue.
+	// ValueEqual is used only with the JSON serialization, since the other formats can't be decoded back.
+	MarshalValue func(v any) ([]byte, error)
+
+	// ValueEqual compares the decoded golden and actual values in AssertValue instead of comparing the JSON texts when set, see EqualWithCmp.
+	// The expected and actual values have the same type as the asserted value.
+	ValueEqual func(t T, expected, actual any, msgAndArgs ...interface{}) bool
+
+	// Recorders are notified about the result of each Assert, see Recorder.
+	Recorders []Recorder
+
+	// Failures collects the failed assertions of the whole run for batch triage, see FailureCollector.
+	Failures *FailureCollector
+
+	// Publisher publishes the failed assertions to an external review service, the returned URL is included in the failure.
+	Publisher Publisher
 }
 
+// MissingFileBehavior selects what happens when the golden file doesn't exist, see FileHandler.MissingFile.
+type MissingFileBehavior int
+
+const (
+	// MissingFileFail fails the assertion.
+	MissingFileFail MissingFileBehavior = iota
+	// MissingFileCreate creates the golden file from the actual data like when recreating.
+	MissingFileCreate
+	// MissingFileSkip skips the test with a message, T must implement Skipf like *testing.T does.
+	// This is useful when incrementally adding golden files to a large legacy suite.
+	MissingFileSkip
+)
+
 type T interface {
 	Logf(format string, args ...any)
 	Errorf(format string, args ...interface{})
@@ -72,77 +274,514 @@ type Client interface {
 //		}
 //	}
 func Request(t T, client Client, req *http.Request, expectedStatusCode int) (*http.Response, bool) {
-	return DefaultHandler.Request(t, client, req, expectedStatusCode)
+	return Default().Request(t, client, req, expectedStatusCode)
 }
 
 // Assert checks the golden file content against the given data.
 func Assert(t T, data string) bool {
-	return DefaultHandler.Assert(t, data)
+	return Default().Assert(t, data)
+}
+
+// WithFS returns a copy of the handler which reads the golden files from the given fs.FS, see FileHandler.FS.
+// This is useful together with embed.FS for environments where testdata isn't shipped alongside the test binary:
+//
+//	//go:embed all:testdata
+//	var testdata embed.FS
+//
+//	var goldenHandler = golden.DefaultHandler.WithFS(testdata)
+//
+// The embedding file can be generated with:
+//
+//	//go:generate go run github.com/go-tstr/golden/cmd/golden embed
+func (h *FileHandler) WithFS(fsys fs.FS) *FileHandler {
+	c := *h
+	c.FS = fsys
+	return &c
+}
+
+// AllowEmpty returns a copy of the handler which accepts empty actual data, see FileHandler.FailOnEmpty.
+func (h *FileHandler) AllowEmpty() *FileHandler {
+	c := *h
+	c.FailOnEmpty = false
+	return &c
 }
 
 func (h *FileHandler) Request(t T, client Client, req *http.Request, expectedStatusCode int) (*http.Response, bool) {
-	resp, err := client.Do(req)
+	resp, err := h.Retry.do(t, client, req)
 	NoError(t, err, "client.Do failed")
 
+	if h.RequestRecorder != nil {
+		h.RequestRecorder.Record(RequestRecord{Test: t.Name(), Method: req.Method, Path: req.URL.Path, StatusCode: resp.StatusCode})
+	}
+	if h.LiveRecorder != nil {
+		h.recordLive(t, req, resp.StatusCode)
+	}
+
 	ok := true
 	if resp.StatusCode != expectedStatusCode {
 		ok = false
 		t.Errorf("expected status code %d, got %d", expectedStatusCode, resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := h.readBody(resp)
+	if errors.Is(err, errBodyTooLarge) {
+		t.Errorf("response body of %s %s exceeds MaxBodySize of %d bytes, Content-Type: %q; check that the endpoint returns the expected content or raise the limit",
+			req.Method, req.URL.Path, h.MaxBodySize, resp.Header.Get("Content-Type"))
+		t.FailNow()
+		return resp, false
+	}
 	NoError(t, err, "reading response body failed")
 
 	resp.Body = io.NopCloser(bytes.NewReader(body))
-	return resp, h.Assert(t, string(body)) && ok
+	return resp, h.transcriptHandler(req, resp).Assert(t, string(body)) && ok
 }
 
 func (h *FileHandler) Assert(t T, data string) bool {
 	t.Helper()
-	if h.ProcessContent != nil {
-		data = h.ProcessContent(t, data)
-	}
-	return h.Equal(t, h.loadAndSaveFile(t, data), data)
+	return h.AssertResult(t, data).Matched
+}
+
+// Result describes the outcome of a golden file assertion.
+type Result struct {
+	// GoldenPath is the path of the golden file.
+	GoldenPath string
+	// WasRecreated tells whether the golden file was written by the assertion.
+	WasRecreated bool
+	// Matched tells whether the actual data matched the golden file content.
+	Matched bool
+	// Diff is the unified diff between the golden file content and the processed actual data, empty when matched.
+	Diff string
+	// GoldenSize is the size of the golden file content in bytes.
+	GoldenSize int
+	// Duration is the time spent comparing the golden file content against the actual data, including the diff.
+	Duration time.Duration
+}
+
+// AssertResult checks the golden file content against the given data like Assert and returns the detailed result,
+// so that callers can act on the outcome programmatically, e.g. attach the diff to a report.
+func AssertResult(t T, data string) Result {
+	return Default().AssertResult(t, data)
 }
 
-func (h *FileHandler) loadAndSaveFile(t T, data string) string {
+func (h *FileHandler) AssertResult(t T, data string) Result {
+	t.Helper()
+	if h.NoOp {
+		return Result{Matched: true}
+	}
+	if h.FailOnEmpty && strings.TrimSpace(data) == "" {
+		t.Errorf("actual data is empty, this usually means that the code under test failed silently, use AllowEmpty if empty output is expected")
+		t.FailNow()
+		return Result{GoldenPath: h.FileName(t)}
+	}
+
+	process, equal := h.ProcessContent, h.Equal
+	if h.MaxDiffLines > 0 {
+		equal = EqualWithTruncatedDiff(h.MaxDiffLines)
+	}
+	if h.ContentTypes != nil {
+		if ct, ok := h.ContentTypes.Lookup([]byte(data)); ok {
+			if ct.ProcessContent != nil {
+				process = ct.ProcessContent
+			}
+			if ct.Equal != nil {
+				equal = ct.Equal
+			}
+		}
+	}
+
+	if process != nil {
+		data = process(t, data)
+	}
+	if len(h.Scrubbers) > 0 {
+		data = Scrub(h.Placeholder, h.Scrubbers...)(t, data)
+	}
+	data = h.BOM.stripBOM(data)
+	if h.ValidateUTF8 && !checkUTF8(t, "actual content", data) {
+		return Result{GoldenPath: h.FileName(t)}
+	}
+
+	raw := data
+	if h.HashOnly {
+		data = ContentHash(data)
+	}
+
 	fileName := h.FileName(t)
-	if h.ShouldRecreate(t) {
-		t.Logf("recreating golden file: %s", fileName)
-		NoError(t, os.MkdirAll(filepath.Dir(fileName), 0o755), "failed to create testdata directory for golden file")
-		NoError(t, os.WriteFile(fileName, []byte(data), 0o600), "failed to write golden file")
+	expected, recreated := h.loadAndSaveFile(t, fileName, data)
+	if h.ValidateUTF8 && !checkUTF8(t, "golden file "+fileName, expected) {
+		return Result{GoldenPath: fileName, WasRecreated: recreated}
+	}
+	diff := h.Diff
+	if diff == nil {
+		diff = UnifiedDiff
+	}
+	if h.HexDumpBinary && (HasNonPrintable(expected) || HasNonPrintable(data)) {
+		equal = EqualHexDump
+		textDiff := diff
+		diff = func(expectedName, actualName, expected, actual string) string {
+			return textDiff(expectedName, actualName, HexDump(expected), HexDump(actual))
+		}
+	}
+
+	msg := failureHint(t, fileName, len(expected))
+	if h.HashOnly && h.DumpActual && expected != data {
+		if dump := dumpActual(t, raw); dump != "" {
+			msg = "actual content written to " + dump + "\n" + msg
+		}
+	}
+	diffFile := ""
+	if h.WriteDiff {
+		diffName := fileName + ".diff"
+		if expected == data {
+			_ = os.Remove(diffName)
+		} else {
+			h.writeArtifact(t, diffName, []byte(diff(fileName, "actual", expected, data)))
+			msg = "full diff written to " + diffName + "\n" + msg
+			diffFile = diffName
+		}
+	}
+	res := Result{GoldenPath: fileName, WasRecreated: recreated, GoldenSize: len(expected)}
+	start := time.Now()
+	res.Matched, res.Diff = h.compare(t, fileName, expected, data, func(t T, expected, actual string) (bool, string) {
+		if equal(t, expected, actual, msg) {
+			return true, ""
+		}
+		return false, diff(fileName, "actual", expected, actual)
+	})
+	res.Duration = time.Since(start)
+	res.Diff = h.cloneMapped(res.Diff)
+	if !res.Matched {
+		h.mismatch(t, Mismatch{GoldenFile: fileName, Expected: h.cloneMapped(expected), Actual: data, DiffFile: diffFile})
+	}
+	for _, r := range h.Recorders {
+		r.RecordResult(t.Name(), res)
+	}
+	return res
+}
+
+// loadAndSaveFile writes the golden file when recreating and returns its content and whether it was recreated.
+func (h *FileHandler) loadAndSaveFile(t T, fileName, data string) (string, bool) {
+	if h.NoOp {
+		return data, false
+	}
+	recreate := h.ShouldRecreate(t)
+	if h.FailOnRecreate && !h.readOnly(t, fileName, recreate) {
+		return "", false
+	}
+	if !recreate && h.MissingFile != MissingFileFail && !h.exists(fileName) {
+		switch h.MissingFile {
+		case MissingFileCreate:
+			t.Logf("golden file %s doesn't exist, creating it", fileName)
+			recreate = true
+		case MissingFileSkip:
+			msg := fmt.Sprintf("golden file %s doesn't exist, skipping, create it with GOLDEN_FILES_RECREATE=true", fileName)
+			if s, ok := t.(interface{ Skipf(string, ...any) }); ok {
+				s.Skipf("%s", msg)
+			}
+			// Skipf stops the test, T implementations which can't skip get the assertion passed.
+			t.Logf("%s", msg)
+			return data, false
+		}
+	}
+
+	if h.Tracker != nil {
+		if err := h.Tracker.Track(t.Name(), fileName, recreate && !fileExists(h.storage(), fileName)); err != nil {
+			NoError(t, err, "golden file tracking failed")
+			return "", false
+		}
+	}
+
+	if out, ok := verifyOutputPath(fileName); ok && recreate {
+		// The committed golden file is kept intact, VerifyClean compares the regenerated one against it.
+		// The regenerated files are temporary, so they're written to the OS filesystem and not uploaded or deduplicated.
+		NoError(t, OSStorage{}.WriteFile(out, []byte(h.goldenContent(fileName, data))), "failed to write regenerated golden file")
+		return data, false
+	}
+
+	if recreate && len(h.ProtectedFiles) > 0 {
+		protected, err := h.protected(fileName)
+		NoError(t, err, "invalid protected golden file pattern")
+		if protected {
+			t.Errorf("golden file %s is protected and can't be recreated, update it through the process for protected fixtures", fileName)
+			t.FailNow()
+			return "", false
+		}
 	}
 
-	b, err := os.ReadFile(fileName)
+	if recreate && h.WritePending {
+		if !h.writePending(t, fileName, h.goldenContent(fileName, data)) {
+			return "", false
+		}
+		return data, false
+	}
+
+	if recreate {
+		if out, ok := bazelOutputPath(fileName); ok {
+			fileName = out.path
+			t.Logf("running under Bazel, golden file written to undeclared test outputs: %s\n%s", fileName, out.instructions())
+		} else {
+			t.Logf("recreating golden file: %s", fileName)
+		}
+		content := h.goldenContent(fileName, data)
+		if err := recreatedFiles.check(t.Name(), fileName, content); err != nil {
+			NoError(t, err, "golden file collision")
+			return "", false
+		}
+		if h.OnRecreate != nil && !h.onRecreate(t, fileName, content) {
+			return "", false
+		}
+		if err := h.storage().WriteFile(fileName, []byte(content)); err != nil {
+			NoError(t, err, "failed to write golden file")
+			return "", false
+		}
+		if err := recreatedFiles.claim(t.Name(), fileName, content); err != nil {
+			NoError(t, err, "golden file collision")
+			return "", false
+		}
+	}
+
+	b, mapped, err := h.readGolden(t, fileName, recreate)
 	NoError(t, err, "failed to read golden file")
-	return string(b)
+	if IsLFSPointer(b) {
+		t.Errorf("golden file %s is a Git LFS pointer, its content wasn't downloaded, install Git LFS and run: git lfs pull", fileName)
+		t.FailNow()
+		return "", false
+	}
+	var expected string
+	if mapped {
+		// the mapped content isn't copied, see cloneMapped for the integrations retaining it after the test
+		expected = unsafe.String(unsafe.SliceData(b), len(b))
+	} else {
+		expected = string(b)
+	}
+	expected = h.BOM.stripBOM(expected)
+	if h.Migrate != nil && !recreate && err == nil {
+		expected = h.migrate(t, fileName, expected)
+	}
+	if h.TemplateData != nil && err == nil {
+		rendered, err := renderGolden(fileName, expected, h.TemplateData)
+		NoError(t, err, "failed to render golden file template")
+		expected = rendered
+	}
+	if h.IgnoreLineMarker != "" {
+		expected = ignoreLines(expected, data, h.IgnoreLineMarker)
+	}
+	return expected, recreate
+}
+
+// goldenContent returns the content written into the golden file when recreating it with the data.
+func (h *FileHandler) goldenContent(fileName, data string) string {
+	content := data
+	if h.IgnoreLineMarker != "" {
+		if old, err := h.readFile(fileName, false); err == nil {
+			content = keepIgnoredLines(string(old), content, h.IgnoreLineMarker)
+		}
+	}
+	if h.TemplateData != nil {
+		content = templatize(content, h.TemplateData)
+	}
+	if h.BOM == BOMAdd {
+		content = BOM + content
+	}
+	return content
+}
+
+// onRecreate calls the OnRecreate hook and reports whether the recreation is allowed.
+func (h *FileHandler) onRecreate(t T, fileName, content string) bool {
+	t.Helper()
+	old, err := h.storage().ReadFile(fileName)
+	if err != nil {
+		old = nil
+	}
+
+	ht := &hookT{T: t}
+	h.OnRecreate(ht, fileName, old, []byte(content))
+	return !ht.failed
+}
+
+// hookT records whether the hook failed the test.
+type hookT struct {
+	T
+	failed bool
+}
+
+func (h *hookT) Errorf(format string, args ...interface{}) {
+	h.T.Helper()
+	h.failed = true
+	h.T.Errorf(format, args...)
+}
+
+func (h *hookT) FailNow() {
+	h.failed = true
+	h.T.FailNow()
+}
+
+// migrate upgrades the legacy golden file content with Migrate and rewrites the file when RewriteMigrated is set.
+func (h *FileHandler) migrate(t T, fileName, content string) string {
+	migrated, ok := h.Migrate(content)
+	if !ok {
+		return content
+	}
+
+	if h.RewriteMigrated {
+		t.Logf("rewriting migrated golden file: %s", fileName)
+		NoError(t, h.storage().WriteFile(fileName, []byte(migrated)), "failed to write migrated golden file")
+	}
+	return migrated
+}
+
+// mismatch runs the optional integrations for reviewing the failed assertion.
+func (h *FileHandler) mismatch(t T, m Mismatch) {
+	t.Helper()
+	m.Test = t.Name()
+	if h.Failures != nil {
+		h.Failures.Record(Failure{Test: m.Test, GoldenFile: m.GoldenFile, DiffFile: m.DiffFile})
+	}
+
+	if h.DiffCommand != nil {
+		if cmd := h.DiffCommand(t); len(cmd) > 0 {
+			runDiffCommand(t, cmd, m.GoldenFile, m.Actual)
+		}
+	}
+
+	if h.Publisher != nil {
+		url, err := h.Publisher.Publish(m)
+		if err != nil {
+			t.Logf("failed to publish the mismatch: %s", err)
+		} else if url != "" {
+			t.Errorf("review the mismatch at %s", url)
+		}
+	}
+}
+
+// failureHint describes the compared golden file and how to update it, it's included in the failure messages.
+func failureHint(t T, fileName string, size int) string {
+	return fmt.Sprintf("golden file: %s (%d bytes)\nupdate with: GOLDEN_FILES_RECREATE=true go test -run '%s' .",
+		fileName, size, runPattern(t))
+}
+
+// runPattern returns the go test -run pattern which selects only the given test.
+func runPattern(t T) string {
+	segments := strings.Split(t.Name(), "/")
+	for i, s := range segments {
+		segments[i] = "^" + regexp.QuoteMeta(s) + "$"
+	}
+	return strings.Join(segments, "/")
+}
+
+// writeArtifact writes a file which helps reviewing the failure, errors are only logged since the artifacts are optional.
+func (h *FileHandler) writeArtifact(t T, fileName string, data []byte) {
+	mode := h.FileMode
+	if mode == 0 {
+		mode = 0o600
+	}
+	if err := os.WriteFile(fileName, data, mode); err != nil {
+		t.Logf("failed to write %s: %s", fileName, err)
+	}
+}
+
+func (h *FileHandler) exists(fileName string) bool {
+	if h.FS == nil {
+		return fileExists(h.storage(), fileName)
+	}
+	_, err := fs.Stat(h.FS, filepath.ToSlash(filepath.Clean(fileName)))
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
+func (h *FileHandler) readFile(fileName string, recreated bool) ([]byte, error) {
+	if h.FS == nil || recreated {
+		return h.storage().ReadFile(fileName)
+	}
+	return fs.ReadFile(h.FS, filepath.ToSlash(filepath.Clean(fileName)))
 }
 
 // TestNameToFilePath creates file name and path for the golden file using t.Name() with following rules:
 // Top level: ./testdata/{testFuncName}/{testFuncName}.golden
 // Subtest:   ./testdata/{testFuncName}/{subTestName}.golden
+// The test fails with ErrPathTraversal when the test name would place the golden file outside of testdata.
 func TestNameToFilePath(t T) string {
-	split := strings.SplitN(t.Name(), "/", 2)
-	mainTestName := t.Name()
-	testName := t.Name()
+	return testPath(t, "./testdata/")
+}
+
+// ErrPathTraversal is reported when the golden file path derived from the test name would escape its base directory,
+// e.g. for a test named "../../etc/x".
+var ErrPathTraversal = errors.New("golden file path escapes the base directory")
+
+// testPath returns the golden file path derived from the test name under the base directory,
+// the test fails when the path would escape it.
+func testPath(t T, base string) string {
+	rel, err := localTestPath(t.Name())
+	if err != nil {
+		NoError(t, err, "invalid golden file path")
+		// T implementations which don't stop the test on FailNow still get a path inside the base directory.
+		rel = strings.ReplaceAll(rel, "..", "__")
+	}
+	return filepath.Join(base, rel)
+}
+
+// localTestPath is testNameToPath which returns ErrPathTraversal when the path isn't local to the base directory.
+func localTestPath(name string) (string, error) {
+	rel := testNameToPath(name)
+	if !filepath.IsLocal(rel) {
+		return rel, fmt.Errorf("%w: test %q maps to %q", ErrPathTraversal, name, rel)
+	}
+	return rel, nil
+}
+
+// testNameToPath converts the test name into golden file path relative to the testdata directory.
+func testNameToPath(name string) string {
+	split := strings.SplitN(name, "/", 2)
+	mainTestName := name
+	testName := name
 	if len(split) == 2 {
 		mainTestName = split[0]
 		testName = strings.ReplaceAll(split[1], "/", "_")
 	}
 
-	return strings.ReplaceAll(filepath.Join("./testdata/", mainTestName, testName+".golden"), " ", "_")
+	return strings.ReplaceAll(filepath.Join(mainTestName, testName+".golden"), " ", "_")
 }
 
 // ParseRecreateFromEnv checks if the environment variable GOLDEN_FILES_RECREATE is set to true.
+// Values which aren't booleans are treated as comma separated test name patterns, only the matching tests are recreated.
+// See MatchTestName for the pattern syntax.
 func ParseRecreateFromEnv(t T) bool {
 	str := os.Getenv("GOLDEN_FILES_RECREATE")
 	if str == "" {
 		return false
 	}
 
-	overwrite, err := strconv.ParseBool(str)
-	NoError(t, err, fmt.Sprintf("failed to parse GOLDEN_FILES_RECREATE env variable: '%s' to bool", str))
-	return overwrite
+	if overwrite, err := strconv.ParseBool(str); err == nil {
+		return overwrite
+	}
+
+	for _, pattern := range strings.Split(str, ",") {
+		ok, err := MatchTestName(strings.TrimSpace(pattern), t.Name())
+		NoError(t, err, fmt.Sprintf("failed to parse GOLDEN_FILES_RECREATE env variable: '%s' to bool or test name pattern", str))
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchTestName reports whether the test name or any of its parent tests match the pattern.
+// The pattern is a path.Match glob, e.g. "TestFunc/subtest*", where "*" doesn't match the "/" separating the subtests.
+// Patterns wrapped in slashes, e.g. "/^TestAPI/(get|list)$/", are regular expressions matched against the full test name.
+func MatchTestName(pattern, name string) (bool, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(name), nil
+	}
+
+	for i := len(name); i > 0; i = strings.LastIndex(name[:i], "/") {
+		ok, err := path.Match(pattern, name[:i])
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
 }
 
 func NoError(t T, err error, msg string) {
@@ -158,10 +797,3 @@ func NoError(t T, err error, msg string) {
 func EqualWithDiff(t T, expected, actual string, msgAndArgs ...interface{}) (ok bool) {
 	return assert.Equal(t, expected, actual, msgAndArgs...)
 }
-
-// PrettyJSON formats the JSON string using the pretty package.
-// This is useful for making the JSON more readable in the golden file
-// also provides cleaner diffs when comparing JSON files.
-func PrettyJSON(t T, data string) string {
-	return string(pretty.Pretty([]byte(data)))
-}
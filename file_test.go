@@ -109,6 +109,37 @@ func TestFile(t *testing.T) {
 	})
 }
 
+func TestParseRecreateFromEnv_Pattern(t *testing.T) {
+	tests := []struct {
+		env  string
+		name string
+		want bool
+	}{
+		{env: "TestFunc/subtest*", name: "TestFunc/subtest_other", want: true},
+		{env: "TestFunc/subtest*", name: "TestFunc/other", want: false},
+		{env: "TestFunc", name: "TestFunc/subtest/nested", want: true},
+		{env: "TestFunc*", name: "TestFuncOther", want: true},
+		{env: "TestOther, TestFunc/*/nested", name: "TestFunc/subtest/nested", want: true},
+		{env: "/^TestAPI/(get|list)$/", name: "TestAPI/list", want: true},
+		{env: "/^TestAPI/(get|list)$/", name: "TestAPI/delete", want: false},
+		{env: "false", name: "TestFunc", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env+" "+tt.name, func(t *testing.T) {
+			t.Setenv("GOLDEN_FILES_RECREATE", tt.env)
+			mt := &mockT{name: tt.name}
+			assert.Equal(t, tt.want, golden.ParseRecreateFromEnv(mt))
+			assert.False(t, mt.failed)
+		})
+	}
+
+	t.Setenv("GOLDEN_FILES_RECREATE", "Test[")
+	mt := &mockT{name: "TestFunc"}
+	assert.False(t, golden.ParseRecreateFromEnv(mt))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "syntax error in pattern")
+}
+
 func TestFolderDoesNotExist(t *testing.T) {
 	mt := mockT{name: "TestDirFail"}
 	golden.Assert(&mt, "data")
@@ -145,6 +176,75 @@ func TestEqual_No_Match(t *testing.T) {
 	assert.True(t, mt.failed)
 }
 
+func TestEqual_FailureHint(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestHint"), "failed to remove testdata") })
+	assert.NoError(t, os.MkdirAll("./testdata/TestHint", 0o755))
+	assert.NoError(t, os.WriteFile("./testdata/TestHint/sub_case.golden", []byte("expected"), 0o600))
+
+	mt := mockT{name: "TestHint/sub_case"}
+	assert.False(t, golden.Assert(&mt, "actual"))
+	assert.Contains(t, mt.msg, "golden file: testdata/TestHint/sub_case.golden (8 bytes)\n")
+	assert.Contains(t, mt.msg, "update with: GOLDEN_FILES_RECREATE=true go test -run '^TestHint$/^sub_case$' .")
+}
+
+func TestAssertResult(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestResult"), "failed to remove testdata") })
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}
+
+	res := fh.AssertResult(&mockT{name: "TestResult"}, "a\n")
+	res.Duration = 0
+	assert.Equal(t, golden.Result{GoldenPath: "testdata/TestResult/TestResult.golden", WasRecreated: true, Matched: true, GoldenSize: 2}, res)
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt := &mockT{name: "TestResult"}
+	res = fh.AssertResult(mt, "b\n")
+	assert.True(t, mt.failed)
+	assert.False(t, res.Matched)
+	assert.False(t, res.WasRecreated)
+	assert.Equal(t, "--- testdata/TestResult/TestResult.golden\n+++ actual\n@@ -1 +1 @@\n-a\n+b\n", res.Diff)
+}
+
+type skipT struct {
+	mockT
+	skipped string
+}
+
+func (s *skipT) Skipf(f string, args ...any) { s.skipped = fmt.Sprintf(f, args...) }
+
+func TestMissingFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+	}
+
+	mt := &skipT{mockT: mockT{name: "TestMissing"}}
+	assert.False(t, fh.Assert(mt, "data"))
+	assert.True(t, mt.failed)
+
+	fh.MissingFile = golden.MissingFileSkip
+	mt = &skipT{mockT: mockT{name: "TestMissing"}}
+	assert.True(t, fh.Assert(mt, "data"))
+	assert.False(t, mt.failed)
+	assert.Equal(t, "golden file testdata/TestMissing/TestMissing.golden doesn't exist, skipping, create it with GOLDEN_FILES_RECREATE=true", mt.skipped)
+	assert.NoFileExists(t, "testdata/TestMissing/TestMissing.golden")
+
+	fh.MissingFile = golden.MissingFileCreate
+	mt = &skipT{mockT: mockT{name: "TestMissing"}}
+	assert.True(t, fh.Assert(mt, "data"))
+	assert.Empty(t, mt.skipped)
+	assert.FileExists(t, "testdata/TestMissing/TestMissing.golden")
+
+	mt = &skipT{mockT: mockT{name: "TestMissing"}}
+	assert.False(t, fh.Assert(mt, "other"), "existing golden files are compared")
+	assert.True(t, mt.failed)
+}
+
 func TestRequest(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "bad request", http.StatusBadRequest)
@@ -202,12 +302,16 @@ type mockT struct {
 	name   string
 	failed bool
 	msg    string
+	logs   string
 }
 
-func (m *mockT) Name() string                       { return m.name }
-func (m *mockT) Logf(f string, args ...interface{}) { fmt.Printf(f, args...) }
-func (m *mockT) FailNow()                           { m.failed = true }
-func (m *mockT) Helper()                            {}
+func (m *mockT) Name() string { return m.name }
+func (m *mockT) FailNow()     { m.failed = true }
+func (m *mockT) Helper()      {}
+func (m *mockT) Logf(f string, args ...interface{}) {
+	fmt.Printf(f, args...)
+	m.logs += "\n" + fmt.Sprintf(f, args...)
+}
 func (m *mockT) Errorf(f string, args ...interface{}) {
 	m.failed = true
 	m.msg += "\n" + fmt.Sprintf(f, args...)
@@ -0,0 +1,71 @@
+package golden_test
+
+import (
+	"io/fs"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions aren't supported on Windows")
+	}
+	t.Chdir(t.TempDir())
+	name := "testdata/TestFileMode/TestFileMode.golden"
+	require.NoError(t, os.MkdirAll("testdata/TestFileMode", 0o700))
+	require.NoError(t, os.WriteFile(name, []byte("old"), 0o600))
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		FileMode:       0o640,
+		DirMode:        0o750,
+	}
+	assert.True(t, fh.Assert(&mockT{name: "TestFileMode"}, "data"))
+	assertPerm(t, name, 0o640)
+
+	assert.True(t, fh.Assert(&mockT{name: "TestFileMode/Sub/Dir"}, "data"))
+	assertPerm(t, "testdata/TestFileMode/Sub_Dir.golden", 0o640)
+
+	s := golden.OSStorage{DirMode: 0o750}
+	require.NoError(t, s.WriteFile("testdata/New/New.golden", []byte("data")))
+	assertPerm(t, "testdata/New", 0o750)
+	assertPerm(t, "testdata/New/New.golden", 0o600)
+}
+
+func assertPerm(t *testing.T, name string, want fs.FileMode) {
+	t.Helper()
+	info, err := os.Stat(name)
+	require.NoError(t, err)
+	assert.Equal(t, want, info.Mode().Perm(), name)
+}
+
+func TestFileMode_Links(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions aren't supported on Windows")
+	}
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestFileMode", 0o755))
+	require.NoError(t, os.WriteFile("shared.golden", []byte("old"), 0o600))
+	require.NoError(t, os.Symlink("../../shared.golden", "testdata/TestFileMode/TestFileMode.golden"))
+	require.NoError(t, os.WriteFile("hard.golden", []byte("old"), 0o600))
+	require.NoError(t, os.Link("hard.golden", "testdata/TestFileMode/hard.golden"))
+
+	s := golden.OSStorage{FileMode: 0o664}
+	require.NoError(t, s.WriteFile("testdata/TestFileMode/TestFileMode.golden", []byte("new")))
+	require.NoError(t, s.WriteFile("testdata/TestFileMode/hard.golden", []byte("new")))
+
+	info, err := os.Lstat("testdata/TestFileMode/TestFileMode.golden")
+	require.NoError(t, err)
+	assert.Equal(t, fs.ModeSymlink, info.Mode().Type(), "symlink is kept")
+	assertFileContent(t, "shared.golden", "new")
+	assertPerm(t, "shared.golden", 0o664)
+	assertFileContent(t, "hard.golden", "new")
+	assertPerm(t, "hard.golden", 0o664)
+}
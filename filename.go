@@ -0,0 +1,135 @@
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BaseDir returns a FileName function which places the golden files under the given directory
+// instead of ./testdata using the same naming rules as TestNameToFilePath:
+// Top level: {baseDir}/{testFuncName}/{testFuncName}.golden
+// Subtest:   {baseDir}/{testFuncName}/{subTestName}.golden
+// The test fails with ErrPathTraversal when the test name would place the golden file outside of baseDir.
+func BaseDir(baseDir string) func(T) string {
+	return func(t T) string {
+		return testPath(t, baseDir)
+	}
+}
+
+// ParamsToFilePath creates file name and path for the golden file from parameterized subtest names,
+// each subtest level becomes a directory and key=value segments are replaced with their values:
+// Top level: ./testdata/{testFuncName}/{testFuncName}.golden
+// Subtest:   ./testdata/{testFuncName}/{value1}/{value2}.golden
+// For example "TestPrice/region=eu/tier=pro" becomes ./testdata/TestPrice/eu/pro.golden.
+// This keeps large parameterized suites navigable instead of producing flat underscore joined file names.
+// The test fails with ErrPathTraversal when a segment would place the golden file outside of its test directory.
+func ParamsToFilePath(t T) string {
+	segments := strings.Split(strings.ReplaceAll(t.Name(), " ", "_"), "/")
+	if len(segments) == 1 {
+		return filepath.Join("./testdata/", segments[0], segments[0]+".golden")
+	}
+
+	for i, s := range segments[1:] {
+		if key, value, ok := strings.Cut(s, "="); ok && value != "" {
+			s = value
+		} else if ok {
+			s = key
+		}
+		if s == "." || !filepath.IsLocal(s) {
+			NoError(t, fmt.Errorf("%w: test %q has segment %q", ErrPathTraversal, t.Name(), s), "invalid golden file path")
+			// T implementations which don't stop the test on FailNow still get a path inside the test directory.
+			s = "_"
+		}
+		segments[i+1] = s
+	}
+	segments[len(segments)-1] += ".golden"
+	return filepath.Join(append([]string{"./testdata/"}, segments...)...)
+}
+
+// ModuleRoot returns a FileName function which places the golden files under the given directory
+// relative to the module root, which is the closest parent directory containing go.mod file.
+// This allows sharing the golden files between packages even when the tests are run from different package directories.
+// Example: ModuleRoot("testdata") resolves to {moduleRoot}/testdata/{testFuncName}/{subTestName}.golden
+func ModuleRoot(dir string) func(T) string {
+	return func(t T) string {
+		root, err := FindModuleRoot()
+		NoError(t, err, "failed to find module root")
+		return testPath(t, filepath.Join(root, dir))
+	}
+}
+
+// SharedFixture returns a FileName function which ignores the test name and always returns the named shared golden file
+// {moduleRoot}/testdata/shared/{name}.golden, so that tests in multiple packages can assert against the same fixture:
+//
+//	h := *golden.DefaultHandler
+//	h.FileName = golden.SharedFixture("user")
+//	h.Assert(t, got)
+//
+// The symlinks in the path are resolved like with ResolveSymlinks.
+func SharedFixture(name string) func(T) string {
+	return ResolveSymlinks(func(t T) string {
+		root, err := FindModuleRoot()
+		NoError(t, err, "failed to find module root")
+		return filepath.Join(root, "testdata", "shared", name+".golden")
+	})
+}
+
+// ResolveSymlinks returns a FileName function which resolves the symlinks in the path returned by fileName,
+// e.g. when testdata is a symlink to a fixture directory shared by multiple packages. The golden files which don't exist
+// yet are resolved through their closest existing parent directory, so they are created in the link target.
+// This way Tracker and the failure messages see the real location of the golden file.
+func ResolveSymlinks(fileName func(T) string) func(T) string {
+	return func(t T) string {
+		name := fileName(t)
+		resolved, err := resolveSymlinks(name)
+		NoError(t, err, "failed to resolve symlinks")
+		return resolved
+	}
+}
+
+func resolveSymlinks(name string) (string, error) {
+	dir, rest := filepath.Clean(name), ""
+	for {
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return name, nil
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+		dir = parent
+	}
+}
+
+// ErrModuleRootNotFound is returned when go.mod file can't be found from the working directory or any of its parents.
+var ErrModuleRootNotFound = errors.New("go.mod not found")
+
+// FindModuleRoot returns the closest directory containing go.mod file starting from the working directory.
+func FindModuleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrModuleRootNotFound
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,152 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseDir(t *testing.T) {
+	fileName := golden.BaseDir("/some/dir")
+	assert.Equal(t, "/some/dir/TestFunc/TestFunc.golden", fileName(&mockT{name: "TestFunc"}))
+	assert.Equal(t, "/some/dir/TestFunc/sub_test_nested.golden", fileName(&mockT{name: "TestFunc/sub test/nested"}))
+}
+
+func TestParamsToFilePath(t *testing.T) {
+	for name, expected := range map[string]string{
+		"TestPrice":                         "testdata/TestPrice/TestPrice.golden",
+		"TestPrice/region=eu/tier=pro":      "testdata/TestPrice/eu/pro.golden",
+		"TestPrice/region=eu/free plan":     "testdata/TestPrice/eu/free_plan.golden",
+		"TestPrice/region=/tier=a=b":        "testdata/TestPrice/region/a=b.golden",
+		"TestPrice/region=eu#01/tier=basic": "testdata/TestPrice/eu#01/basic.golden",
+	} {
+		mt := &mockT{name: name}
+		assert.Equal(t, expected, golden.ParamsToFilePath(mt), name)
+		assert.False(t, mt.failed, name)
+	}
+
+	for _, name := range []string{"TestPrice/region=../tier=..", "TestPrice/region=./tier=x"} {
+		mt := &mockT{name: name}
+		path := golden.ParamsToFilePath(mt)
+		assert.True(t, mt.failed, name)
+		assert.Contains(t, mt.msg, golden.ErrPathTraversal.Error(), name)
+		assert.True(t, filepath.IsLocal(path), "fallback path %q stays under testdata", path)
+	}
+}
+
+func TestModuleRoot(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	root, err := golden.FindModuleRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wd, root)
+
+	mt := &mockT{name: "TestFunc/subtest"}
+	assert.Equal(t, filepath.Join(wd, "fixtures", "TestFunc", "subtest.golden"), golden.ModuleRoot("fixtures")(mt))
+	assert.False(t, mt.failed)
+}
+
+func TestModuleRoot_FromSubDir(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	t.Chdir("testdata")
+	root, err := golden.FindModuleRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wd, root)
+}
+
+func TestModuleRoot_NotFound(t *testing.T) {
+	t.Chdir(t.TempDir())
+	mt := &mockT{name: "TestFunc"}
+	golden.ModuleRoot("testdata")(mt)
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, golden.ErrModuleRootNotFound.Error())
+}
+
+func TestResolveSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require extra privileges on Windows")
+	}
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("fixtures", 0o755))
+	require.NoError(t, os.MkdirAll("pkg", 0o755))
+	require.NoError(t, os.Symlink("../fixtures", "pkg/testdata"))
+	target, err := filepath.EvalSymlinks("fixtures")
+	require.NoError(t, err)
+
+	fh := &golden.FileHandler{
+		FileName:       golden.ResolveSymlinks(golden.BaseDir("pkg/testdata")),
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}
+	mt := &mockT{name: "TestFunc/sub"}
+	assert.Equal(t, filepath.Join(target, "TestFunc", "sub.golden"), fh.FileName(mt))
+	assert.True(t, fh.Assert(mt, "data"))
+	assert.FileExists(t, "fixtures/TestFunc/sub.golden")
+	assert.False(t, mt.failed)
+}
+
+func TestSharedFixture(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/shared\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0o755))
+	root, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+
+	fh := &golden.FileHandler{
+		FileName:       golden.SharedFixture("user"),
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+	}
+	expected := filepath.Join(root, "testdata", "shared", "user.golden")
+	require.NoError(t, golden.OSStorage{}.WriteFile(expected, []byte("data")))
+
+	for _, wd := range []string{"a", filepath.Join("a", "b")} {
+		t.Chdir(filepath.Join(dir, wd))
+		for _, name := range []string{"TestA", "TestB/sub"} {
+			mt := &mockT{name: name}
+			assert.Equal(t, expected, fh.FileName(mt))
+			assert.True(t, fh.Assert(mt, "data"))
+			assert.False(t, mt.failed)
+		}
+	}
+}
+
+func TestPathTraversal(t *testing.T) {
+	for name, expected := range map[string]string{
+		"TestX/../../etc/x": "testdata/TestX/.._.._etc_x.golden",
+		"TestX/..":          "testdata/TestX/...golden",
+		"TestX/.":           "testdata/TestX/..golden",
+		`TestX/..\..\x`:     `testdata/TestX/..\..\x.golden`,
+	} {
+		if runtime.GOOS == "windows" && strings.Contains(name, `\`) {
+			continue
+		}
+		mt := &mockT{name: name}
+		assert.Equal(t, filepath.FromSlash(expected), golden.TestNameToFilePath(mt), name)
+		assert.False(t, mt.failed, name)
+	}
+
+	for _, name := range []string{"../../etc/x", "..", "../x"} {
+		for _, fileName := range []func(golden.T) string{golden.TestNameToFilePath, golden.BaseDir("fixtures")} {
+			mt := &mockT{name: name}
+			path := fileName(mt)
+			assert.True(t, mt.failed, name)
+			assert.Contains(t, mt.msg, golden.ErrPathTraversal.Error(), name)
+			assert.True(t, filepath.IsLocal(path), "fallback path %q stays under the base directory", path)
+		}
+	}
+}
+
+func TestPlanRename_PathTraversal(t *testing.T) {
+	_, err := golden.PlanRename("testdata", map[string]string{"TestOld": "../TestNew"}, nil)
+	assert.ErrorIs(t, err, golden.ErrPathTraversal)
+}
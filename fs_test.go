@@ -0,0 +1,55 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFS(t *testing.T) {
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		FS: fstest.MapFS{
+			"testdata/TestEmbedded/TestEmbedded.golden": {Data: []byte("embedded data")},
+		},
+	}
+
+	mt := &mockT{name: "TestEmbedded"}
+	assert.True(t, fh.Assert(mt, "embedded data"))
+	assert.False(t, mt.failed)
+	assert.NoDirExists(t, "./testdata/TestEmbedded")
+
+	mt = &mockT{name: "TestEmbeddedMissing"}
+	assert.False(t, fh.Assert(mt, "data"))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "open testdata/TestEmbeddedMissing/TestEmbeddedMissing.golden: file does not exist")
+}
+
+func TestFS_Recreate(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestEmbedded"), "failed to remove testdata") })
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		FS: fstest.MapFS{
+			"testdata/TestEmbedded/TestEmbedded.golden": {Data: []byte("stale data")},
+		},
+	}
+
+	mt := &mockT{name: "TestEmbedded"}
+	assert.True(t, fh.Assert(mt, "new data"))
+	assert.False(t, mt.failed)
+	assert.FileExists(t, "./testdata/TestEmbedded/TestEmbedded.golden")
+}
+
+func TestWithFS(t *testing.T) {
+	fsys := fstest.MapFS{}
+	fh := golden.DefaultHandler.WithFS(fsys)
+	assert.Equal(t, fsys, fh.FS)
+	assert.Nil(t, golden.DefaultHandler.FS)
+}
@@ -0,0 +1,108 @@
+package golden
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FuzzDir is the directory where the go command looks for the fuzz corpus seeds, relative to the package directory.
+const FuzzDir = "testdata/fuzz"
+
+// Fuzzer is implemented by *testing.F.
+type Fuzzer interface {
+	Add(args ...any)
+}
+
+// AddGoldenSeeds adds the content of each golden file matching the pattern as a string seed to the fuzz corpus, e.g.
+//
+//	func FuzzParse(f *testing.F) {
+//		golden.NoError(f, golden.AddGoldenSeeds(f, "testdata/TestParse/*.golden"), "failed to add seeds")
+//		f.Fuzz(func(t *testing.T, data string) { ... })
+//	}
+//
+// Files are added in lexical order, the pattern syntax is the same as in filepath.Match.
+func AddGoldenSeeds(f Fuzzer, pattern string) error {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, name := range files {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		f.Add(string(b))
+	}
+	return nil
+}
+
+// AssertFuzzSeed checks the golden file content against the data like Assert
+// and on mismatch writes the input and the actual data as a seed into the corpus of the given fuzz target, see WriteFuzzSeed.
+// The seed is then run as a regular test case by FuzzTarget(f *testing.F) with f.Fuzz(func(t *testing.T, input, output string)).
+func AssertFuzzSeed(t T, fuzzTarget, input, data string) bool {
+	return Default().AssertFuzzSeed(t, fuzzTarget, input, data)
+}
+
+func (h *FileHandler) AssertFuzzSeed(t T, fuzzTarget, input, data string) bool {
+	t.Helper()
+	if h.Assert(t, data) {
+		return true
+	}
+
+	name, err := WriteFuzzSeed(FuzzDir, fuzzTarget, input, data)
+	if err != nil {
+		t.Logf("failed to write fuzz seed: %s", err)
+		return false
+	}
+	t.Logf("failing input written to fuzz corpus: %s", name)
+	return false
+}
+
+// WriteFuzzSeed writes the arguments in the go fuzz corpus file format into {dir}/{fuzzTarget}/ and returns the file name.
+// The file is named after the hash of its content, so writing the same seed twice doesn't create duplicates.
+// Supported argument types are the ones accepted by testing.F.Add: string, []byte, bool and the numeric types.
+func WriteFuzzSeed(dir, fuzzTarget string, args ...any) (string, error) {
+	sb := &strings.Builder{}
+	sb.WriteString("go test fuzz v1\n")
+	for _, arg := range args {
+		s, err := fuzzValue(arg)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(s + "\n")
+	}
+
+	data := []byte(sb.String())
+	name := filepath.Join(dir, fuzzTarget, fmt.Sprintf("%x", sha256.Sum256(data))[:16])
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", err
+	}
+	return name, os.WriteFile(name, data, 0o600)
+}
+
+func fuzzValue(arg any) (string, error) {
+	switch v := arg.(type) {
+	case string:
+		return "string(" + strconv.Quote(v) + ")", nil
+	case []byte:
+		return "[]byte(" + strconv.Quote(string(v)) + ")", nil
+	case byte:
+		return "byte(" + strconv.QuoteRune(rune(v)) + ")", nil
+	case rune:
+		return "rune(" + strconv.QuoteRune(v) + ")", nil
+	case float32:
+		return "float32(" + strconv.FormatFloat(float64(v), 'g', -1, 32) + ")", nil
+	case float64:
+		return "float64(" + strconv.FormatFloat(v, 'g', -1, 64) + ")", nil
+	case bool, int, int8, int16, int64, uint, uint16, uint32, uint64:
+		return fmt.Sprintf("%T(%v)", v, v), nil
+	default:
+		return "", fmt.Errorf("unsupported fuzz argument type %T", arg)
+	}
+}
@@ -0,0 +1,82 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type seedCollector struct {
+	seeds [][]any
+}
+
+func (c *seedCollector) Add(args ...any) {
+	c.seeds = append(c.seeds, args)
+}
+
+func TestAddGoldenSeeds(t *testing.T) {
+	c := &seedCollector{}
+	require.NoError(t, golden.AddGoldenSeeds(c, "testdata/TestErrorTree/*.golden"))
+	require.NotEmpty(t, c.seeds)
+	for _, seed := range c.seeds {
+		assert.Len(t, seed, 1)
+		assert.IsType(t, "", seed[0])
+	}
+
+	assert.Error(t, golden.AddGoldenSeeds(c, "[invalid"))
+}
+
+func TestWriteFuzzSeed(t *testing.T) {
+	dir := t.TempDir()
+	name, err := golden.WriteFuzzSeed(dir, "FuzzParse", "in\n\"quoted\"", []byte("out"), 42, true, 1.5, byte('a'), 'ä')
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "FuzzParse"), filepath.Dir(name))
+
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"go test fuzz v1",
+		`string("in\n\"quoted\"")`,
+		`[]byte("out")`,
+		"int(42)",
+		"bool(true)",
+		"float64(1.5)",
+		"byte('a')",
+		"rune('ä')",
+		"",
+	}, "\n"), string(b))
+
+	again, err := golden.WriteFuzzSeed(dir, "FuzzParse", "in\n\"quoted\"", []byte("out"), 42, true, 1.5, byte('a'), 'ä')
+	require.NoError(t, err)
+	assert.Equal(t, name, again)
+
+	_, err = golden.WriteFuzzSeed(dir, "FuzzParse", struct{}{})
+	assert.Error(t, err)
+}
+
+func TestAssertFuzzSeed(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}
+	mt := &mockT{name: "TestFuzzSeed"}
+	assert.True(t, fh.AssertFuzzSeed(mt, "FuzzParse", "input", "output"))
+	assert.NoDirExists(t, golden.FuzzDir)
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt = &mockT{name: "TestFuzzSeed"}
+	assert.False(t, fh.AssertFuzzSeed(mt, "FuzzParse", "input", "changed"))
+	seeds, err := os.ReadDir(filepath.Join(golden.FuzzDir, "FuzzParse"))
+	require.NoError(t, err)
+	require.Len(t, seeds, 1)
+	b, err := os.ReadFile(filepath.Join(golden.FuzzDir, "FuzzParse", seeds[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, "go test fuzz v1\nstring(\"input\")\nstring(\"changed\")\n", string(b))
+}
@@ -0,0 +1,242 @@
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GeoOptions configures the geometry normalization done by GeoJSONProcessor and WKTProcessor.
+type GeoOptions struct {
+	// Precision is the number of decimals coordinates are rounded to, defaults to 6 which is about 10cm for degrees.
+	Precision int
+}
+
+func (o GeoOptions) precision() int {
+	if o.Precision <= 0 {
+		return 6
+	}
+	return o.Precision
+}
+
+// GeoJSONProcessor returns ProcessContent function which canonicalizes GeoJSON: coordinates and bounding boxes are
+// rounded to the configured precision, polygon rings are rewound to follow the RFC 7946 right-hand rule
+// (exterior rings counterclockwise, holes clockwise) and the features of feature collections are sorted by id.
+// Numeric ids are sorted before string ids and features without id keep their order after them. The result is formatted like PrettyJSON with sorted keys.
+func GeoJSONProcessor(opts GeoOptions) func(T, string) string {
+	return func(t T, data string) string {
+		dec := json.NewDecoder(strings.NewReader(data))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			NoError(t, err, "failed to parse GeoJSON")
+			return data
+		}
+
+		v = normalizeGeoJSON(v, opts.precision())
+		b, err := json.Marshal(v)
+		NoError(t, err, "failed to encode GeoJSON")
+		return PrettyJSON(t, string(b))
+	}
+}
+
+func normalizeGeoJSON(v any, precision int) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, child := range x {
+			switch k {
+			case "coordinates", "bbox":
+				x[k] = roundGeoNumbers(child, precision)
+			default:
+				x[k] = normalizeGeoJSON(child, precision)
+			}
+		}
+
+		switch x["type"] {
+		case "Polygon":
+			if rings, ok := x["coordinates"].([]any); ok {
+				rewindPolygon(rings)
+			}
+		case "MultiPolygon":
+			if polygons, ok := x["coordinates"].([]any); ok {
+				for _, p := range polygons {
+					if rings, ok := p.([]any); ok {
+						rewindPolygon(rings)
+					}
+				}
+			}
+		case "FeatureCollection":
+			if features, ok := x["features"].([]any); ok {
+				sort.SliceStable(features, func(i, j int) bool {
+					return geoFeatureLess(features[i], features[j])
+				})
+			}
+		}
+		return x
+	case []any:
+		for i, child := range x {
+			x[i] = normalizeGeoJSON(child, precision)
+		}
+		return x
+	default:
+		return v
+	}
+}
+
+// geoFeatureLess orders the features by id: numeric ids numerically first, then string ids and the features without id last.
+func geoFeatureLess(a, b any) bool {
+	ra, na, sa := geoFeatureID(a)
+	rb, nb, sb := geoFeatureID(b)
+	if ra != rb {
+		return ra < rb
+	}
+	if ra == 0 {
+		return na < nb
+	}
+	return sa < sb
+}
+
+// geoFeatureID returns the rank of the id kind: 0 for numbers, 1 for strings and 2 for missing id, and the id value.
+func geoFeatureID(feature any) (int, float64, string) {
+	m, _ := feature.(map[string]any)
+	switch id := m["id"].(type) {
+	case json.Number:
+		if f, err := id.Float64(); err == nil {
+			return 0, f, ""
+		}
+		return 1, 0, id.String()
+	case string:
+		return 1, 0, id
+	default:
+		return 2, 0, ""
+	}
+}
+
+func roundGeoNumbers(v any, precision int) any {
+	switch x := v.(type) {
+	case []any:
+		for i, child := range x {
+			x[i] = roundGeoNumbers(child, precision)
+		}
+		return x
+	case json.Number:
+		f, err := x.Float64()
+		if err != nil {
+			return x
+		}
+		return json.Number(roundFloat(f, precision, strings.ContainsAny(string(x), "eE")))
+	default:
+		return v
+	}
+}
+
+// roundFloat rounds the number to the given number of decimals. The numbers written with an exponent keep
+// the exponent form when it's shorter, so e.g. 1e300 isn't expanded to 301 digits.
+func roundFloat(f float64, precision int, exponent bool) string {
+	// Numbers this large have no fractional part and scaling them could change their last digits.
+	if math.Abs(f) < 1<<52 {
+		scale := math.Pow(10, float64(precision))
+		f = math.Round(f*scale) / scale
+	}
+	if f == 0 {
+		// Avoids rendering negative zero as "-0".
+		f = 0
+	}
+	if exponent {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// rewindPolygon makes the first ring counterclockwise and the rest clockwise.
+func rewindPolygon(rings []any) {
+	for i, r := range rings {
+		ring, ok := r.([]any)
+		if !ok {
+			continue
+		}
+		clockwise := ringArea(ring) > 0
+		if (i == 0) == clockwise {
+			for a, b := 0, len(ring)-1; a < b; a, b = a+1, b-1 {
+				ring[a], ring[b] = ring[b], ring[a]
+			}
+		}
+	}
+}
+
+// ringArea returns the shoelace sum of the ring, positive for clockwise rings.
+func ringArea(ring []any) float64 {
+	coord := func(p any, i int) float64 {
+		pos, ok := p.([]any)
+		if !ok || len(pos) <= i {
+			return 0
+		}
+		n, _ := pos[i].(json.Number)
+		f, _ := n.Float64()
+		return f
+	}
+
+	sum := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		sum += (coord(ring[i+1], 0) - coord(ring[i], 0)) * (coord(ring[i+1], 1) + coord(ring[i], 1))
+	}
+	return sum
+}
+
+// WKTProcessor returns ProcessContent function which canonicalizes Well-Known Text geometries, including the EWKT
+// SRID prefix: keywords are uppercased, numbers rounded to the configured precision and the whitespace normalized,
+// e.g. "polygon((1.0000001 2,3 4))" becomes "POLYGON ((1 2, 3 4))". Each line is processed separately.
+func WKTProcessor(opts GeoOptions) func(T, string) string {
+	return func(t T, data string) string {
+		lines := strings.Split(data, "\n")
+		for i, line := range lines {
+			lines[i] = normalizeWKT(line, opts.precision())
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+func normalizeWKT(line string, precision int) string {
+	out := &bytes.Buffer{}
+	var prev byte // kind of the previous token: 'w' word, 'n' number or the punctuation itself
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == ',' || c == ';' || c == '=':
+			if c == '(' && (prev == 'w' || prev == 'n') {
+				out.WriteByte(' ')
+			}
+			out.WriteByte(c)
+			if c == ',' {
+				out.WriteByte(' ')
+			}
+			prev = c
+			i++
+		default:
+			j := i
+			for j < len(line) && !strings.ContainsRune(" \t\r(),;=", rune(line[j])) {
+				j++
+			}
+			tok := line[i:j]
+			kind := byte('w')
+			if f, err := strconv.ParseFloat(tok, 64); err == nil && (unicode.IsDigit(rune(tok[0])) || strings.ContainsRune("+-.", rune(tok[0]))) {
+				tok, kind = roundFloat(f, precision, strings.ContainsAny(tok, "eE")), 'n'
+			} else {
+				tok = strings.ToUpper(tok)
+			}
+			if prev == 'w' || prev == 'n' || (prev == ')' && kind == 'w') {
+				out.WriteByte(' ')
+			}
+			out.WriteString(tok)
+			prev = kind
+			i = j
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,40 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoJSONProcessor(t *testing.T) {
+	const data = `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "id": 10, "properties": {"name": "b", "population": 12345678901234567890},
+		 "geometry": {"type": "Point", "coordinates": [24.94102811, 60.17332440]}},
+		{"type": "Feature", "properties": {"name": "no id"}, "geometry": null},
+		{"type": "Feature", "id": 2, "properties": {"name": "a"},
+		 "geometry": {"type": "Polygon", "coordinates": [
+			[[0, 0], [0, 10], [10, 10], [10, 0], [0, 0]],
+			[[2, 2], [4, 2], [4, 4], [2, 4], [2, 2]]
+		 ]}}
+	]}`
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: golden.ParseRecreateFromEnv,
+		Equal:          golden.EqualWithDiff,
+		ProcessContent: golden.GeoJSONProcessor(golden.GeoOptions{Precision: 5}),
+	}
+	fh.Assert(t, data)
+
+	mt := &mockT{name: "TestGeoJSONProcessor"}
+	golden.GeoJSONProcessor(golden.GeoOptions{})(mt, "{")
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "failed to parse GeoJSON")
+}
+
+func TestWKTProcessor(t *testing.T) {
+	process := golden.WKTProcessor(golden.GeoOptions{Precision: 2})
+	mt := &mockT{name: "TestWKTProcessor"}
+	assert.Equal(t, "POLYGON ((1 2, 3.14 4, 1 2))\nSRID=4326;POINT Z (1 -2 3)\nGEOMETRYCOLLECTION (POINT (1 1), LINESTRING EMPTY)",
+		process(mt, "polygon((1.0000001 2,3.14159   4 , 1 2))\nsrid=4326;Point z(1 -2.001 3)\nGeometryCollection(POINT(1 1),linestring empty)"))
+}
@@ -0,0 +1,102 @@
+package golden
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"strings"
+	"time"
+)
+
+// AssertGIF decodes the animated GIF and checks the golden file content against the snapshot rendered by GIFSnapshot.
+// Frames are hashed with the handler's Image.Hash, DHash is used when it isn't set.
+func AssertGIF(t T, data []byte, n int) bool {
+	return Default().AssertGIF(t, data, n)
+}
+
+func (h *FileHandler) AssertGIF(t T, data []byte, n int) bool {
+	t.Helper()
+	hash := h.Image.Hash
+	if hash == nil {
+		hash = DHash
+	}
+	snapshot, err := GIFSnapshot(data, n, hash)
+	if err != nil {
+		NoError(t, err, "failed to decode GIF")
+		return false
+	}
+	return h.Assert(t, snapshot)
+}
+
+// GIFSnapshot renders the metadata of the animated GIF and the perceptual hashes of n evenly spaced frames,
+// all the frames are included when n <= 0. Frames are composited the way viewers display them,
+// so the hashes don't depend on how the encoder optimized the frames.
+func GIFSnapshot(data []byte, n int, hash func(image.Image) uint64) (string, error) {
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	var total time.Duration
+	starts := make([]time.Duration, len(g.Image))
+	for i, d := range g.Delay {
+		starts[i] = total
+		total += time.Duration(d) * 10 * time.Millisecond
+	}
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "size: %dx%d\nframes: %d\nduration: %s\nloop count: %d\n", g.Config.Width, g.Config.Height, len(g.Image), total, g.LoopCount)
+
+	frames := gifFrames(g)
+	for _, i := range sampleIndexes(len(frames), n) {
+		fmt.Fprintf(sb, "frame %d at %s: %016x\n", i, starts[i], hash(frames[i]))
+	}
+	return sb.String(), nil
+}
+
+// gifFrames composites the frames on the canvas taking the disposal methods into account.
+func gifFrames(g *gif.GIF) []image.Image {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	canvas := image.NewRGBA(bounds)
+	frames := make([]image.Image, len(g.Image))
+	for i, frame := range g.Image {
+		var previous *image.RGBA
+		if i < len(g.Disposal) && g.Disposal[i] == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			draw.Draw(previous, bounds, canvas, image.Point{}, draw.Src)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		snapshot := image.NewRGBA(bounds)
+		draw.Draw(snapshot, bounds, canvas, image.Point{}, draw.Src)
+		frames[i] = snapshot
+
+		if i < len(g.Disposal) {
+			switch g.Disposal[i] {
+			case gif.DisposalBackground:
+				draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+			case gif.DisposalPrevious:
+				canvas = previous
+			}
+		}
+	}
+	return frames
+}
+
+// sampleIndexes returns n evenly spaced indexes including the first and the last one, all the indexes when n <= 0.
+func sampleIndexes(length, n int) []int {
+	if n <= 0 || n > length {
+		n = length
+	}
+	indexes := make([]int, 0, n)
+	for i := range n {
+		if n == 1 {
+			indexes = append(indexes, 0)
+			break
+		}
+		indexes = append(indexes, i*(length-1)/(n-1))
+	}
+	return indexes
+}
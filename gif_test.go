@@ -0,0 +1,63 @@
+package golden_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertGIF(t *testing.T) {
+	golden.AssertGIF(t, animatedGIF(t, 10), 4)
+}
+
+func TestGIFSnapshot(t *testing.T) {
+	data := animatedGIF(t, 5)
+	all, err := golden.GIFSnapshot(data, 0, golden.DHash)
+	require.NoError(t, err)
+	assert.Contains(t, all, "frames: 5\nduration: 500ms\n")
+	assert.Contains(t, all, "frame 4 at 400ms")
+
+	sampled, err := golden.GIFSnapshot(data, 2, golden.DHash)
+	require.NoError(t, err)
+	assert.Contains(t, sampled, "frame 0 at 0s")
+	assert.Contains(t, sampled, "frame 4 at 400ms")
+	assert.NotContains(t, sampled, "frame 2")
+
+	mt := &mockT{name: "TestGIFSnapshot"}
+	assert.False(t, golden.AssertGIF(mt, []byte("GIF89a"), 1))
+	assert.Contains(t, mt.msg, "failed to decode GIF")
+}
+
+// animatedGIF returns animation of a bar moving from left to right, the frames only contain the changed area.
+func animatedGIF(t *testing.T, frames int) []byte {
+	palette := color.Palette{color.White, color.Black}
+	g := &gif.GIF{Config: image.Config{Width: 64, Height: 32, ColorModel: palette}}
+	for i := range frames {
+		x := i * 64 / frames
+		rect := image.Rect(0, 0, 64, 32)
+		if i > 0 {
+			rect = image.Rect(x-64/frames, 0, min(x+8, 64), 32)
+		}
+		frame := image.NewPaletted(rect, palette)
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for px := rect.Min.X; px < rect.Max.X; px++ {
+				if px >= x && px < x+8 {
+					frame.SetColorIndex(px, y, 1)
+				}
+			}
+		}
+		g.Image = append(g.Image, frame)
+		g.Delay = append(g.Delay, 10)
+		g.Disposal = append(g.Disposal, gif.DisposalNone)
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, gif.EncodeAll(buf, g))
+	return buf.Bytes()
+}
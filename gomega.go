@@ -0,0 +1,92 @@
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MatchGolden returns Gomega matcher which checks the actual value against the golden file using the default handler,
+// see Default. T names the golden file and receives the logs, e.g. GinkgoT() in Ginkgo specs:
+//
+//	Expect(out).To(golden.MatchGolden(GinkgoT()))
+//
+// Supported actual values are string, []byte, error and fmt.Stringer.
+func MatchGolden(t T) *GoldenMatcher {
+	return Default().MatchGolden(t)
+}
+
+// MatchGolden returns Gomega matcher which checks the actual value against the golden file using the handler.
+func (h *FileHandler) MatchGolden(t T) *GoldenMatcher {
+	return &GoldenMatcher{handler: h, t: t}
+}
+
+// GoldenMatcher implements Gomega's types.GomegaMatcher interface without depending on Gomega.
+type GoldenMatcher struct {
+	handler *FileHandler
+	t       T
+	failure string
+}
+
+// Match asserts the actual value against the golden file, the failures are reported through FailureMessage.
+// Fatal failures, e.g. when the golden file can't be read, are returned as error.
+func (m *GoldenMatcher) Match(actual any) (ok bool, err error) {
+	if m.t == nil {
+		return false, fmt.Errorf("MatchGolden requires T, e.g. GinkgoT()")
+	}
+
+	var data string
+	switch x := actual.(type) {
+	case string:
+		data = x
+	case []byte:
+		data = string(x)
+	case error:
+		data = x.Error()
+	case fmt.Stringer:
+		data = x.String()
+	default:
+		return false, fmt.Errorf("MatchGolden expects string, []byte, error or fmt.Stringer, got %T", actual)
+	}
+
+	mt := &matcherT{T: m.t}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, fatal := r.(matcherFailNow); !fatal {
+				panic(r)
+			}
+			ok, err = false, errors.New(strings.Join(mt.errors, "\n"))
+		}
+		m.failure = strings.Join(mt.errors, "\n")
+	}()
+	ok = m.handler.Assert(mt, data)
+	return ok && len(mt.errors) == 0, nil
+}
+
+// FailureMessage returns the failure reported by the handler.
+func (m *GoldenMatcher) FailureMessage(_ any) string {
+	return m.failure
+}
+
+// NegatedFailureMessage is returned when the actual value unexpectedly matches the golden file.
+func (m *GoldenMatcher) NegatedFailureMessage(_ any) string {
+	return "expected not to match the golden file"
+}
+
+// matcherT collects the failures instead of failing the test, so Gomega can report them.
+type matcherT struct {
+	T
+	errors []string
+}
+
+func (m *matcherT) Errorf(format string, args ...interface{}) {
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
+}
+
+// FailNow stops the assertion like testing.T.FailNow does, Match recovers and returns the recorded failures.
+func (m *matcherT) FailNow() {
+	panic(matcherFailNow{})
+}
+
+// matcherFailNow is the panic value of matcherT.FailNow.
+type matcherFailNow struct{}
@@ -0,0 +1,55 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gomegaMatcher is a copy of Gomega's types.GomegaMatcher interface.
+type gomegaMatcher interface {
+	Match(actual interface{}) (success bool, err error)
+	FailureMessage(actual interface{}) (message string)
+	NegatedFailureMessage(actual interface{}) (message string)
+}
+
+var _ gomegaMatcher = golden.MatchGolden(nil)
+
+func TestMatchGolden(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestGomega"), "failed to remove testdata") })
+	require.NoError(t, os.MkdirAll("./testdata/TestGomega", 0o755))
+	require.NoError(t, os.WriteFile("./testdata/TestGomega/TestGomega.golden", []byte("expected"), 0o600))
+
+	mt := &mockT{name: "TestGomega"}
+	m := golden.MatchGolden(mt)
+	ok, err := m.Match([]byte("expected"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = m.Match("actual")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, m.FailureMessage("actual"), "Not equal:")
+	assert.False(t, mt.failed, "failures are reported through the matcher")
+
+	_, err = m.Match(1)
+	assert.ErrorContains(t, err, "got int")
+}
+
+func TestMatchGolden_FailNow(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	mt := &mockT{name: "TestGomegaMissing"}
+	ok, err := golden.DefaultHandler.ReadOnly().MatchGolden(mt).Match("data")
+	assert.False(t, ok)
+	assert.ErrorContains(t, err, "doesn't exist")
+	assert.False(t, mt.failed, "failures are reported through the matcher")
+}
+
+func TestMatchGolden_NoT(t *testing.T) {
+	_, err := golden.MatchGolden(nil).Match("data")
+	assert.ErrorContains(t, err, "MatchGolden requires T")
+}
@@ -0,0 +1,72 @@
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"go/scanner"
+	"strings"
+)
+
+// FormatGoSource formats the generated Go source with go/format, so code generators don't fail the golden tests
+// over whitespace gofmt doesn't care about. Syntax errors fail the test with the offending lines.
+// Use EqualGoSource as Equal to format the golden file too, e.g. when it's edited by hand.
+func FormatGoSource(t T, data string) string {
+	formatted, err := formatGoSource(data)
+	if err != nil {
+		NoError(t, err, "failed to format Go source")
+		return data
+	}
+	return formatted
+}
+
+// EqualGoSource formats both the golden file and the actual content with go/format before comparing them with EqualWithDiff.
+func EqualGoSource(t T, expected, actual string, msgAndArgs ...interface{}) (ok bool) {
+	t.Helper()
+	formattedExpected, err := formatGoSource(expected)
+	if err != nil {
+		NoError(t, err, "failed to format golden Go source")
+		return false
+	}
+	formattedActual, err := formatGoSource(actual)
+	if err != nil {
+		NoError(t, err, "failed to format actual Go source")
+		return false
+	}
+	return EqualWithDiff(t, formattedExpected, formattedActual, msgAndArgs...)
+}
+
+// formatGoSource formats the source, the syntax errors are rendered with the offending source lines.
+func formatGoSource(src string) (string, error) {
+	b, err := format.Source([]byte(src))
+	if err == nil {
+		return string(b), nil
+	}
+
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return "", err
+	}
+	lines := strings.Split(src, "\n")
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "%d syntax error(s)", len(list))
+	for _, e := range list {
+		fmt.Fprintf(sb, "\n%d:%d: %s", e.Pos.Line, e.Pos.Column, e.Msg)
+		if e.Pos.Line >= 1 && e.Pos.Line <= len(lines) {
+			line := lines[e.Pos.Line-1]
+			fmt.Fprintf(sb, "\n\t%s\n\t%s^", line, caretIndent(line, e.Pos.Column))
+		}
+	}
+	return "", errors.New(sb.String())
+}
+
+// caretIndent returns the whitespace placing the caret under the given 1-based byte column, tabs are kept as is.
+func caretIndent(line string, column int) string {
+	indent := []byte(line[:min(max(column-1, 0), len(line))])
+	for i, c := range indent {
+		if c != '\t' {
+			indent[i] = ' '
+		}
+	}
+	return string(indent)
+}
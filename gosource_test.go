@@ -0,0 +1,34 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatGoSource(t *testing.T) {
+	mt := &mockT{name: "TestFormatGoSource"}
+	assert.Equal(t, "package main\n\nfunc main() { println(1) }\n",
+		golden.FormatGoSource(mt, "package main\nfunc   main( ) {println( 1 )}"))
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestFormatGoSource"}
+	src := "package main\n\nfunc main() {\n\tx := \n}\n"
+	assert.Equal(t, src, golden.FormatGoSource(mt, src))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "failed to format Go source: 1 syntax error(s)\n5:1: expected operand, found '}'\n\t}\n\t^")
+}
+
+func TestEqualGoSource(t *testing.T) {
+	mt := &mockT{name: "TestEqualGoSource"}
+	assert.True(t, golden.EqualGoSource(mt, "package x\nvar a = 1", "package x\n\nvar a  =  1\n"))
+	assert.False(t, mt.failed)
+
+	assert.False(t, golden.EqualGoSource(mt, "package x\nvar a = 1", "package x\nvar a = 2"))
+	assert.Contains(t, mt.msg, "+var a = 2")
+
+	mt = &mockT{name: "TestEqualGoSource"}
+	assert.False(t, golden.EqualGoSource(mt, "package x\nvar a = ", "package x\nvar a = 1"))
+	assert.Contains(t, mt.msg, "failed to format golden Go source")
+}
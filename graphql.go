@@ -0,0 +1,110 @@
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GraphQL posts the query with the variables to the endpoint and checks the golden file content against
+// the exchange rendered by GraphQLTranscript. The query and variables are stored in the golden file too,
+// so the golden file documents what was asked. Response's top level extensions, e.g. tracing and query cost,
+// are removed unless FileHandler.KeepGraphQLExtensions is set.
+func GraphQL(t T, client Client, endpoint, query string, variables map[string]any) (*http.Response, bool) {
+	return Default().GraphQL(t, client, endpoint, query, variables)
+}
+
+func (h *FileHandler) GraphQL(t T, client Client, endpoint, query string, variables map[string]any) (*http.Response, bool) {
+	t.Helper()
+	reqBody, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	NoError(t, err, "failed to encode GraphQL request")
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(reqBody))
+	NoError(t, err, "failed to create GraphQL request")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/graphql-response+json, application/json")
+
+	resp, err := h.Retry.do(t, client, req)
+	NoError(t, err, "client.Do failed")
+
+	if h.RequestRecorder != nil {
+		h.RequestRecorder.Record(RequestRecord{Test: t.Name(), Method: req.Method, Path: req.URL.Path, StatusCode: resp.StatusCode})
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	NoError(t, err, "reading response body failed")
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	transcript, err := GraphQLTranscript(query, variables, resp.StatusCode, body, h.KeepGraphQLExtensions)
+	if err != nil {
+		NoError(t, err, "failed to parse GraphQL response")
+		return resp, false
+	}
+	return resp, h.Assert(t, transcript)
+}
+
+// GraphQLTranscript renders the GraphQL query with the common indentation removed, variables, response status code and the formatted response.
+// Response's top level fields are rendered in the order errors, data, extensions and the nested fields in the
+// order sent by the server, which follows the query.
+func GraphQLTranscript(query string, variables map[string]any, statusCode int, response []byte, keepExtensions bool) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(response, &fields); err != nil {
+		return "", err
+	}
+	if !keepExtensions {
+		delete(fields, "extensions")
+	}
+
+	var parts []string
+	for _, key := range []string{"errors", "data", "extensions"} {
+		if v, ok := fields[key]; ok {
+			parts = append(parts, fmt.Sprintf("%q:%s", key, v))
+		}
+	}
+
+	sb := &strings.Builder{}
+	sb.WriteString("# query\n" + dedent(query) + "\n")
+	if len(variables) > 0 {
+		vars, err := json.Marshal(variables)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString("# variables\n" + PrettyJSON(nil, string(vars)))
+	}
+	fmt.Fprintf(sb, "# response %d\n", statusCode)
+	sb.WriteString(PrettyJSON(nil, "{"+strings.Join(parts, ",")+"}"))
+	return sb.String(), nil
+}
+
+// dedent trims the leading and trailing blank lines and removes the indentation common to all the non-blank lines,
+// so queries written as indented raw string literals don't depend on the indentation of the test code.
+func dedent(s string) string {
+	lines := strings.Split(strings.Trim(s, "\n"), "\n")
+	var prefix *string
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if prefix == nil {
+			prefix = &indent
+			continue
+		}
+		n := 0
+		for n < len(*prefix) && n < len(indent) && (*prefix)[n] == indent[n] {
+			n++
+		}
+		common := indent[:n]
+		prefix = &common
+	}
+	for i, line := range lines {
+		if prefix != nil {
+			line = strings.TrimPrefix(line, *prefix)
+		}
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,48 @@
+package golden_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func graphQLServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"extensions": {"tracing": {"duration": 12345}}, "data": {"user": {"name": "someone", "id": "` +
+			req.Variables["id"].(string) + `"}}, "errors": [{"message": "field deprecated", "extensions": {"code": "DEPRECATED"}}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGraphQL(t *testing.T) {
+	query := `
+		query User($id: ID!) {
+			user(id: $id) { name id }
+		}`
+	_, ok := golden.GraphQL(t, http.DefaultClient, graphQLServer(t).URL, query, map[string]any{"id": "1"})
+	assert.True(t, ok)
+}
+
+func TestGraphQLTranscript(t *testing.T) {
+	got, err := golden.GraphQLTranscript("{ ok }", nil, http.StatusOK, []byte(`{"extensions": {"cost": 1}, "data": {"ok": true}}`), true)
+	require.NoError(t, err)
+	assert.Equal(t, "# query\n{ ok }\n# response 200\n{\n  \"data\": {\n    \"ok\": true\n  },\n  \"extensions\": {\n    \"cost\": 1\n  }\n}\n", got)
+
+	_, err = golden.GraphQLTranscript("{ ok }", nil, http.StatusBadGateway, []byte("bad gateway"), false)
+	assert.Error(t, err)
+}
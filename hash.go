@@ -0,0 +1,29 @@
+package golden
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+)
+
+// ContentHash returns the golden file content stored in the hash-only mode, see FileHandler.HashOnly.
+// It contains the SHA-256 hash and the size of the data, so that the size change is visible in the diff.
+func ContentHash(data string) string {
+	return fmt.Sprintf("sha256: %x\nsize: %d\n", sha256.Sum256([]byte(data)), len(data))
+}
+
+// dumpActual writes the actual content into a temporary file for inspecting hash-only mismatches and returns its path.
+func dumpActual(t T, data string) string {
+	f, err := os.CreateTemp("", "golden-*.actual")
+	if err != nil {
+		t.Logf("failed to dump the actual content: %s", err)
+		return ""
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(data); err != nil {
+		t.Logf("failed to dump the actual content: %s", err)
+		return ""
+	}
+	return f.Name()
+}
@@ -0,0 +1,45 @@
+package golden_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashOnly(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		HashOnly:       true,
+		DumpActual:     true,
+	}
+
+	assert.True(t, fh.Assert(&mockT{name: "TestHashOnly"}, "huge output"))
+	b, err := os.ReadFile("testdata/TestHashOnly/TestHashOnly.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256: e006b96d1729dce9379be3be82d20f7805355d144763622c8b99d6a89e2b6c9e\nsize: 11\n", string(b))
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt := &mockT{name: "TestHashOnly"}
+	assert.True(t, fh.Assert(mt, "huge output"))
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestHashOnly"}
+	assert.False(t, fh.Assert(mt, "other output"))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "-size: 11")
+	assert.Contains(t, mt.msg, "+size: 12")
+
+	m := regexp.MustCompile(`actual content written to (\S+)`).FindStringSubmatch(mt.msg)
+	require.Len(t, m, 2)
+	t.Cleanup(func() { assert.NoError(t, os.Remove(m[1])) })
+	b, err = os.ReadFile(m[1])
+	require.NoError(t, err)
+	assert.Equal(t, "other output", string(b))
+}
@@ -0,0 +1,69 @@
+package golden
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// HeaderOptions configures RenderHeaders.
+type HeaderOptions struct {
+	// Include renders only the given headers, all the headers are rendered when empty.
+	Include []string
+	// Exclude skips the given headers, e.g. the ones changing between the runs like Date.
+	Exclude []string
+	// JoinValues renders the multiple values of a header on a single line joined with ", ",
+	// by default each value is rendered on its own line. Set-Cookie values are never joined since they can contain commas.
+	JoinValues bool
+}
+
+// RenderHeaders renders the headers deterministically, one "Name: value" line per value: the names are canonicalized,
+// e.g. content-type becomes Content-Type, and sorted, and the values of a header keep their original order.
+func RenderHeaders(header http.Header, opts HeaderOptions) string {
+	include := canonicalHeaderKeys(opts.Include)
+	exclude := canonicalHeaderKeys(opts.Exclude)
+
+	// the source keys are sorted, so the values of non-canonical duplicates, e.g. x-id and X-Id, are merged in a stable order
+	sourceKeys := make([]string, 0, len(header))
+	for k := range header {
+		sourceKeys = append(sourceKeys, k)
+	}
+	sort.Strings(sourceKeys)
+
+	values := map[string][]string{}
+	for _, k := range sourceKeys {
+		v := header[k]
+		k = http.CanonicalHeaderKey(k)
+		if (len(include) > 0 && !slices.Contains(include, k)) || slices.Contains(exclude, k) {
+			continue
+		}
+		values[k] = append(values[k], v...)
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sb := &strings.Builder{}
+	for _, k := range keys {
+		if opts.JoinValues && k != "Set-Cookie" {
+			sb.WriteString(k + ": " + strings.Join(values[k], ", ") + "\n")
+			continue
+		}
+		for _, v := range values[k] {
+			sb.WriteString(k + ": " + v + "\n")
+		}
+	}
+	return sb.String()
+}
+
+func canonicalHeaderKeys(keys []string) []string {
+	canonical := make([]string, len(keys))
+	for i, k := range keys {
+		canonical[i] = http.CanonicalHeaderKey(k)
+	}
+	return canonical
+}
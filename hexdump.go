@@ -0,0 +1,68 @@
+package golden
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// HexDump renders the data as canonical hex+ASCII dump like hexdump -C, 16 bytes per line prefixed with the offset
+// and followed by the printable ASCII characters, the last line is the total size.
+func HexDump(data string) string {
+	sb := &strings.Builder{}
+	for offset := 0; offset < len(data); offset += 16 {
+		line := data[offset:min(offset+16, len(data))]
+		fmt.Fprintf(sb, "%08x ", offset)
+		for i := range 16 {
+			if i == 8 {
+				sb.WriteByte(' ')
+			}
+			if i < len(line) {
+				fmt.Fprintf(sb, " %02x", line[i])
+			} else {
+				sb.WriteString("   ")
+			}
+		}
+		sb.WriteString("  |")
+		for i := range len(line) {
+			if c := line[i]; c >= 0x20 && c < 0x7f {
+				sb.WriteByte(c)
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteString("|\n")
+	}
+	fmt.Fprintf(sb, "%08x\n", len(data))
+	return sb.String()
+}
+
+// HasNonPrintable reports whether the data isn't valid UTF-8 or contains control characters other than
+// tabs and line breaks, e.g. binary data passed as string.
+func HasNonPrintable(data string) bool {
+	if !utf8.ValidString(data) {
+		return true
+	}
+	for _, r := range data {
+		if r != '\n' && r != '\r' && r != '\t' && unicode.IsControl(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// EqualHexDump compares the data byte by byte and reports the mismatch as unified diff of the HexDump of the contents.
+func EqualHexDump(t T, expected, actual string, msgAndArgs ...interface{}) (ok bool) {
+	t.Helper()
+	if expected == actual {
+		return true
+	}
+
+	msg := "Not equal (hex dump):\n" + UnifiedDiff("expected", "actual", HexDump(expected), HexDump(actual))
+	if len(msgAndArgs) > 0 {
+		msg += "\n" + messageFromMsgAndArgs(msgAndArgs...)
+	}
+	t.Errorf("%s", msg)
+	return false
+}
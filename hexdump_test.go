@@ -0,0 +1,58 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexDump(t *testing.T) {
+	assert.Equal(t, "00000000\n", golden.HexDump(""))
+	assert.Equal(t,
+		"00000000  00 01 02 68 65 6c 6c 6f  0a ff 20 77 6f 72 6c 64  |...hello.. world|\n"+
+			"00000010  21                                                |!|\n"+
+			"00000011\n",
+		golden.HexDump("\x00\x01\x02hello\n\xff world!"))
+}
+
+func TestHasNonPrintable(t *testing.T) {
+	assert.False(t, golden.HasNonPrintable("héllo\tworld\r\n"))
+	assert.True(t, golden.HasNonPrintable("a\x00b"))
+	assert.True(t, golden.HasNonPrintable("a\x1b[31mb"))
+	assert.True(t, golden.HasNonPrintable("a\xffb"))
+}
+
+func TestHexDumpBinary(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		HexDumpBinary:  true,
+	}
+
+	mt := &mockT{name: "TestBinary"}
+	require.True(t, fh.Assert(mt, "\x89PNG\r\n\x1a\n\x00\x00"))
+	b, err := os.ReadFile("testdata/TestBinary/TestBinary.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "\x89PNG\r\n\x1a\n\x00\x00", string(b), "golden file stores the raw bytes")
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt = &mockT{name: "TestBinary"}
+	res := fh.AssertResult(mt, "\x89PNG\r\n\x1a\n\x00\x01")
+	assert.False(t, res.Matched)
+	assert.Contains(t, mt.msg, "Not equal (hex dump)")
+	assert.Contains(t, res.Diff, "-00000000  89 50 4e 47 0d 0a 1a 0a  00 00")
+	assert.Contains(t, res.Diff, "+00000000  89 50 4e 47 0d 0a 1a 0a  00 01")
+
+	mt = &mockT{name: "TestText"}
+	fh.ShouldRecreate = func(golden.T) bool { return true }
+	fh.Assert(mt, "text")
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	res = fh.AssertResult(mt, "other")
+	assert.NotContains(t, mt.msg, "hex dump")
+	assert.Contains(t, res.Diff, "+other")
+}
@@ -0,0 +1,45 @@
+package golden_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnRecreate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	type call struct{ path, old, new string }
+	var calls []call
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		OnRecreate: func(t golden.T, path string, old, new []byte) {
+			calls = append(calls, call{path: path, old: string(old), new: string(new)})
+			if strings.Contains(path, "Protected") {
+				t.Errorf("recreating %s is not allowed", path)
+			}
+		},
+	}
+
+	mt := &mockT{name: "TestHook"}
+	assert.True(t, fh.Assert(mt, "v1"))
+	assert.True(t, fh.Assert(mt, "v2"))
+	assert.Equal(t, []call{
+		{path: "testdata/TestHook/TestHook.golden", old: "", new: "v1"},
+		{path: "testdata/TestHook/TestHook.golden", old: "v1", new: "v2"},
+	}, calls)
+
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestProtected/TestProtected.golden", []byte("keep")))
+	mt = &mockT{name: "TestProtected"}
+	assert.False(t, fh.Assert(mt, "changed"))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "recreating testdata/TestProtected/TestProtected.golden is not allowed")
+	b, err := os.ReadFile("testdata/TestProtected/TestProtected.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "keep", string(b))
+}
@@ -0,0 +1,143 @@
+package golden
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// PrettyHTML formats the HTML document or fragment with two space indentation, one element per line, e.g. for
+// templating and server-side rendering tests. The attributes are sorted by name and the whitespace in the text is
+// collapsed, so the formatting of the original content and the attribute order don't affect the golden file.
+// Elements containing only text are kept on a single line, the content of pre, textarea, script and style is kept as is.
+// Documents starting with a doctype or the html element are rendered as parsed, including the implied head and body.
+func PrettyHTML(t T, data string) string {
+	var nodes []*html.Node
+	trimmed := strings.ToLower(strings.TrimSpace(data))
+	if strings.HasPrefix(trimmed, "<!doctype") || strings.HasPrefix(trimmed, "<html") {
+		doc, err := html.Parse(strings.NewReader(data))
+		if err != nil {
+			NoError(t, err, "failed to parse HTML")
+			return data
+		}
+		for c := doc.FirstChild; c != nil; c = c.NextSibling {
+			nodes = append(nodes, c)
+		}
+	} else {
+		var err error
+		nodes, err = html.ParseFragment(strings.NewReader(data), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+		if err != nil {
+			NoError(t, err, "failed to parse HTML")
+			return data
+		}
+	}
+
+	sb := &strings.Builder{}
+	for _, n := range nodes {
+		writeHTMLNode(sb, n, 0)
+	}
+	return sb.String()
+}
+
+var htmlWhitespace = regexp.MustCompile(`\s+`)
+
+func writeHTMLNode(sb *strings.Builder, n *html.Node, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch n.Type {
+	case html.DoctypeNode:
+		sb.WriteString(indent + "<!DOCTYPE " + n.Data + ">\n")
+	case html.CommentNode:
+		sb.WriteString(indent + "<!--" + n.Data + "-->\n")
+	case html.TextNode:
+		if text := strings.TrimSpace(htmlWhitespace.ReplaceAllString(n.Data, " ")); text != "" {
+			sb.WriteString(indent + html.EscapeString(text) + "\n")
+		}
+	case html.ElementNode:
+		sb.WriteString(indent)
+		writeHTMLStart(sb, n)
+		if isVoidHTMLElement(n.DataAtom) {
+			sb.WriteString("\n")
+			return
+		}
+
+		switch {
+		case isRawHTMLElement(n.DataAtom):
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if n.DataAtom == atom.Script || n.DataAtom == atom.Style {
+					sb.WriteString(c.Data)
+				} else {
+					writeRawHTMLNode(sb, c)
+				}
+			}
+		case n.FirstChild == nil:
+		case n.FirstChild == n.LastChild && n.FirstChild.Type == html.TextNode:
+			sb.WriteString(html.EscapeString(strings.TrimSpace(htmlWhitespace.ReplaceAllString(n.FirstChild.Data, " "))))
+		default:
+			sb.WriteString("\n")
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				writeHTMLNode(sb, c, depth+1)
+			}
+			sb.WriteString(indent)
+		}
+		sb.WriteString("</" + n.Data + ">\n")
+	}
+}
+
+// writeRawHTMLNode writes the content of pre and textarea elements without reformatting it,
+// e.g. the code element of a code block.
+func writeRawHTMLNode(sb *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		sb.WriteString(html.EscapeString(n.Data))
+	case html.CommentNode:
+		sb.WriteString("<!--" + n.Data + "-->")
+	case html.ElementNode:
+		writeHTMLStart(sb, n)
+		if isVoidHTMLElement(n.DataAtom) {
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			writeRawHTMLNode(sb, c)
+		}
+		sb.WriteString("</" + n.Data + ">")
+	}
+}
+
+func writeHTMLStart(sb *strings.Builder, n *html.Node) {
+	attrs := append([]html.Attribute(nil), n.Attr...)
+	sort.SliceStable(attrs, func(i, j int) bool {
+		if attrs[i].Namespace != attrs[j].Namespace {
+			return attrs[i].Namespace < attrs[j].Namespace
+		}
+		return attrs[i].Key < attrs[j].Key
+	})
+
+	sb.WriteString("<" + n.Data)
+	for _, a := range attrs {
+		sb.WriteString(" ")
+		if a.Namespace != "" {
+			sb.WriteString(a.Namespace + ":")
+		}
+		sb.WriteString(a.Key)
+		if a.Val != "" {
+			sb.WriteString(`="` + html.EscapeString(a.Val) + `"`)
+		}
+	}
+	sb.WriteString(">")
+}
+
+func isVoidHTMLElement(a atom.Atom) bool {
+	switch a {
+	case atom.Area, atom.Base, atom.Br, atom.Col, atom.Embed, atom.Hr, atom.Img, atom.Input,
+		atom.Link, atom.Meta, atom.Source, atom.Track, atom.Wbr:
+		return true
+	}
+	return false
+}
+
+func isRawHTMLElement(a atom.Atom) bool {
+	return a == atom.Pre || a == atom.Textarea || a == atom.Script || a == atom.Style
+}
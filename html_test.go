@@ -0,0 +1,67 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrettyHTML(t *testing.T) {
+	mt := &mockT{name: "TestPrettyHTML"}
+	a := golden.PrettyHTML(mt, `<div id="main"   class="card"><h1>  Hello
+		<b>world</b></h1><img src="a.png" alt="A &amp; B"><p>Tom &amp; Jerry</p>
+		<pre>  keep
+  this</pre><!-- note --><input disabled type="checkbox"></div>`)
+	b := golden.PrettyHTML(mt, `<div class="card" id="main">
+  <h1>Hello <b>world</b></h1>
+  <img alt="A &amp; B" src="a.png" />
+  <p>Tom &amp; Jerry</p>
+  <pre>  keep
+  this</pre><!-- note -->
+  <input type="checkbox" disabled>
+</div>`)
+	assert.False(t, mt.failed)
+	assert.Equal(t, a, b)
+	assert.Equal(t, `<div class="card" id="main">
+  <h1>
+    Hello
+    <b>world</b>
+  </h1>
+  <img alt="A &amp; B" src="a.png">
+  <p>Tom &amp; Jerry</p>
+  <pre>  keep
+  this</pre>
+  <!-- note -->
+  <input disabled type="checkbox">
+</div>
+`, a)
+}
+
+func TestPrettyHTML_Document(t *testing.T) {
+	mt := &mockT{name: "TestPrettyHTML"}
+	assert.Equal(t, `<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <title>Title</title>
+    <script>if (a < b) { run() }</script>
+  </head>
+  <body>
+    <p>text</p>
+  </body>
+</html>
+`, golden.PrettyHTML(mt, `<!doctype html><html lang=en><title>Title</title><script>if (a < b) { run() }</script><p>text`))
+}
+
+func TestPrettyHTML_CodeBlock(t *testing.T) {
+	mt := &mockT{name: "TestPrettyHTML"}
+	assert.Equal(t, `<div>
+  <pre><code class="language-go">func main() {
+	fmt.Println(&#34;a &lt; b&#34;) <!-- note --><br>
+}</code></pre>
+</div>
+`, golden.PrettyHTML(mt, `<div><pre><code class="language-go">func main() {
+	fmt.Println("a &lt; b") <!-- note --><br>
+}</code></pre></div>`))
+	assert.False(t, mt.failed)
+}
@@ -0,0 +1,213 @@
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// transcriptHandler returns the response handler which prepends the requested parts of the response transcript to the processed body.
+func (h *FileHandler) transcriptHandler(req *http.Request, resp *http.Response) *FileHandler {
+	rh := h.responseHandler(resp)
+	if !h.RecordRedirects && h.RecordHeaders == nil && !h.RecordCookies {
+		return rh
+	}
+
+	transcript := ""
+	if h.RecordRedirects {
+		transcript += RedirectTranscript(req, resp)
+	}
+	if h.RecordHeaders != nil {
+		transcript += RenderHeaders(resp.Header, *h.RecordHeaders)
+	}
+	if h.RecordCookies {
+		var style PlaceholderStyle
+		if h.ScrubCookieValues {
+			style = h.Placeholder
+			if style == nil {
+				style = AngleBrackets
+			}
+		}
+		transcript += CookieTranscript(resp, style)
+	}
+
+	c := *rh
+	c.ProcessContent = func(t T, data string) string {
+		if rh.ProcessContent != nil {
+			data = rh.ProcessContent(t, data)
+		}
+		return transcript + "\n" + data
+	}
+	return &c
+}
+
+// CookieTranscript renders the cookies set by the response, one Set-Cookie line per cookie with the attributes
+// in a fixed order:
+//
+//	Set-Cookie: session=abc; Path=/; Max-Age=3600; HttpOnly; Secure; SameSite=Lax
+//
+// The values and expiration times are replaced with VALUE and EXPIRES placeholders rendered with the style when it's not nil.
+func CookieTranscript(resp *http.Response, style PlaceholderStyle) string {
+	sb := &strings.Builder{}
+	for _, c := range resp.Cookies() {
+		value, expires := c.Value, c.RawExpires
+		if style != nil {
+			value, expires = style("VALUE"), style("EXPIRES")
+		}
+
+		fmt.Fprintf(sb, "Set-Cookie: %s=%s", c.Name, value)
+		if c.Path != "" {
+			fmt.Fprintf(sb, "; Path=%s", c.Path)
+		}
+		if c.Domain != "" {
+			fmt.Fprintf(sb, "; Domain=%s", c.Domain)
+		}
+		if c.RawExpires != "" {
+			fmt.Fprintf(sb, "; Expires=%s", expires)
+		}
+		if c.MaxAge > 0 {
+			fmt.Fprintf(sb, "; Max-Age=%d", c.MaxAge)
+		} else if c.MaxAge < 0 {
+			sb.WriteString("; Max-Age=0")
+		}
+		if c.HttpOnly {
+			sb.WriteString("; HttpOnly")
+		}
+		if c.Secure {
+			sb.WriteString("; Secure")
+		}
+		if c.Partitioned {
+			sb.WriteString("; Partitioned")
+		}
+		switch c.SameSite {
+		case http.SameSiteLaxMode:
+			sb.WriteString("; SameSite=Lax")
+		case http.SameSiteStrictMode:
+			sb.WriteString("; SameSite=Strict")
+		case http.SameSiteNoneMode:
+			sb.WriteString("; SameSite=None")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// RedirectTranscript renders the redirect chain which led to the response, one line per hop with the status code
+// and the Location header, followed by the final request and status code:
+//
+//	GET /old -> 301 Location: /new
+//	GET /new -> 200
+//
+// The hops are read from resp.Request.Response like http.Client sets them. URLs are rendered without the scheme
+// and the host when they match the ones of the original request, so the transcript is stable with test servers.
+func RedirectTranscript(req *http.Request, resp *http.Response) string {
+	var hops []*http.Response
+	for r := resp; r != nil; {
+		hops = append(hops, r)
+		if r.Request == nil {
+			break
+		}
+		r = r.Request.Response
+	}
+
+	sb := &strings.Builder{}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := hops[i]
+		hopReq := hop.Request
+		if hopReq == nil {
+			hopReq = req
+		}
+		fmt.Fprintf(sb, "%s %s -> %d", hopReq.Method, transcriptURL(req, hopReq), hop.StatusCode)
+		if i > 0 {
+			fmt.Fprintf(sb, " Location: %s", hop.Header.Get("Location"))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func transcriptURL(orig, req *http.Request) string {
+	if req.URL.Host == "" || (req.URL.Host == orig.URL.Host && req.URL.Scheme == orig.URL.Scheme) {
+		return req.URL.RequestURI()
+	}
+	return req.URL.String()
+}
+
+var errBodyTooLarge = errors.New("response body too large")
+
+// readBody reads the response body, at most MaxBodySize bytes when it's set.
+func (h *FileHandler) readBody(resp *http.Response) ([]byte, error) {
+	if h.MaxBodySize <= 0 {
+		return io.ReadAll(resp.Body)
+	}
+	if resp.ContentLength > h.MaxBodySize {
+		return nil, errBodyTooLarge
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, h.MaxBodySize+1))
+	if err == nil && int64(len(body)) > h.MaxBodySize {
+		return nil, errBodyTooLarge
+	}
+	return body, err
+}
+
+// responseHandler returns the handler used for asserting the response body, see FileHandler.ProcessResponses.
+func (h *FileHandler) responseHandler(resp *http.Response) *FileHandler {
+	if !h.ProcessResponses && h.ResponseProcessors == nil {
+		return h
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return h
+	}
+
+	c := *h
+	if process, ok := h.ResponseProcessors[mediaType]; ok {
+		c.ProcessContent = process
+		return &c
+	}
+	if !h.ProcessResponses {
+		return h
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		c.ProcessContent = PrettyJSON
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		c.ProcessContent = PrettyXML
+	case strings.HasPrefix(mediaType, "text/"):
+		c.ProcessContent = nil
+	default:
+		return h
+	}
+	return &c
+}
+
+// Handler serves the request with the handler using httptest.ResponseRecorder and asserts the response like Request,
+// so handler level tests don't need a running server:
+//
+//	req := httptest.NewRequest(http.MethodGet, "/api/v1/user/1", nil)
+//	golden.Handler(t, mux, req, http.StatusOK)
+func Handler(t T, handler http.Handler, req *http.Request, expectedStatusCode int) (*http.Response, bool) {
+	return Default().Handler(t, handler, req, expectedStatusCode)
+}
+
+func (h *FileHandler) Handler(t T, handler http.Handler, req *http.Request, expectedStatusCode int) (*http.Response, bool) {
+	return h.Request(t, handlerClient{handler: handler}, req, expectedStatusCode)
+}
+
+// handlerClient implements Client by serving the requests directly with the handler.
+type handlerClient struct {
+	handler http.Handler
+}
+
+func (c handlerClient) Do(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
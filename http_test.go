@@ -0,0 +1,320 @@
+package golden_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "` + r.PathValue("id") + `"}`))
+	})
+
+	resp, ok := golden.Handler(t, mux, httptest.NewRequest(http.MethodGet, "/user/1", nil), http.StatusOK)
+	assert.True(t, ok)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "1"}`, string(body))
+
+	mt := &mockT{name: "TestHandler"}
+	_, ok = golden.Handler(mt, mux, httptest.NewRequest(http.MethodGet, "/user/1", nil), http.StatusCreated)
+	assert.False(t, ok)
+	assert.Contains(t, mt.msg, "expected status code 201, got 200")
+}
+
+func TestHandler_ProcessResponses(t *testing.T) {
+	t.Cleanup(func() {
+		assert.NoError(t, os.RemoveAll("./testdata/TestProcessResponses"), "failed to remove testdata")
+	})
+	mux := http.NewServeMux()
+	respond := func(contentType, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = w.Write([]byte(body))
+		}
+	}
+	mux.HandleFunc("/json", respond("application/problem+json; charset=utf-8", `{"title":"not found"}`))
+	mux.HandleFunc("/xml", respond("text/xml", `<user><name>someone</name></user>`))
+	mux.HandleFunc("/text", respond("text/plain", "  as is  "))
+	mux.HandleFunc("/csv", respond("text/csv", "b,a\n"))
+	mux.HandleFunc("/other", respond("application/octet-stream", "other"))
+
+	fh := &golden.FileHandler{
+		FileName:           golden.TestNameToFilePath,
+		ShouldRecreate:     func(golden.T) bool { return true },
+		Equal:              golden.EqualWithDiff,
+		ProcessContent:     func(_ golden.T, s string) string { return strings.ToUpper(s) },
+		ProcessResponses:   true,
+		ResponseProcessors: map[string]func(golden.T, string) string{"text/csv": func(_ golden.T, s string) string { return "csv:" + s }},
+	}
+
+	for path, expected := range map[string]string{
+		"/json":  "{\n  \"title\": \"not found\"\n}\n",
+		"/xml":   "<user>\n  <name>someone</name>\n</user>\n",
+		"/text":  "  as is  ",
+		"/csv":   "csv:b,a\n",
+		"/other": "OTHER",
+	} {
+		mt := &mockT{name: "TestProcessResponses" + path}
+		_, ok := fh.Handler(mt, mux, httptest.NewRequest(http.MethodGet, path, nil), http.StatusOK)
+		assert.True(t, ok, path)
+		b, err := os.ReadFile(golden.TestNameToFilePath(mt))
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(b), path)
+	}
+}
+
+func TestHandler_MaxBodySize(t *testing.T) {
+	t.Chdir(t.TempDir())
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+	})
+	mux.HandleFunc("GET /sized", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+	})
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		MaxBodySize:    99,
+	}
+
+	for _, path := range []string{"/download", "/sized"} {
+		mt := &mockT{name: "TestMaxBodySize"}
+		_, ok := fh.Handler(mt, mux, httptest.NewRequest(http.MethodGet, path, nil), http.StatusOK)
+		assert.False(t, ok)
+		assert.True(t, mt.failed)
+		assert.Contains(t, mt.msg, "response body of GET "+path+" exceeds MaxBodySize of 99 bytes")
+		assert.NoFileExists(t, "testdata/TestMaxBodySize/TestMaxBodySize.golden")
+	}
+
+	fh.MaxBodySize = 100
+	mt := &mockT{name: "TestMaxBodySize"}
+	_, ok := fh.Handler(mt, mux, httptest.NewRequest(http.MethodGet, "/download", nil), http.StatusOK)
+	assert.True(t, ok)
+	assert.False(t, mt.failed)
+}
+
+func TestRequest_RecordRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /old", http.RedirectHandler("/new?x=1", http.StatusMovedPermanently))
+	mux.Handle("GET /new", http.RedirectHandler("/final", http.StatusFound))
+	mux.HandleFunc("GET /final", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"page":"final"}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	t.Chdir(t.TempDir())
+
+	fh := &golden.FileHandler{
+		FileName:         golden.TestNameToFilePath,
+		ShouldRecreate:   func(golden.T) bool { return true },
+		Equal:            golden.EqualWithDiff,
+		ProcessResponses: true,
+		RecordRedirects:  true,
+	}
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/old", nil)
+	require.NoError(t, err)
+	_, ok := fh.Request(&mockT{name: "TestRedirects"}, http.DefaultClient, req, http.StatusOK)
+	assert.True(t, ok)
+
+	b, err := os.ReadFile("testdata/TestRedirects/TestRedirects.golden")
+	require.NoError(t, err)
+	assert.Equal(t, `GET /old -> 301 Location: /new?x=1
+GET /new?x=1 -> 302 Location: /final
+GET /final -> 200
+
+{
+  "page": "final"
+}
+`, string(b))
+}
+
+func TestHandler_RecordCookies(t *testing.T) {
+	t.Chdir(t.TempDir())
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "f81d4fae", Path: "/", MaxAge: 3600, HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode})
+		http.SetCookie(w, &http.Cookie{Name: "old", Path: "/app", MaxAge: -1})
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	fh := &golden.FileHandler{
+		FileName:          golden.TestNameToFilePath,
+		ShouldRecreate:    func(golden.T) bool { return true },
+		Equal:             golden.EqualWithDiff,
+		RecordCookies:     true,
+		ScrubCookieValues: true,
+	}
+
+	_, ok := fh.Handler(&mockT{name: "TestCookies"}, mux, httptest.NewRequest(http.MethodPost, "/login", nil), http.StatusOK)
+	assert.True(t, ok)
+	b, err := os.ReadFile("testdata/TestCookies/TestCookies.golden")
+	require.NoError(t, err)
+	assert.Equal(t, `Set-Cookie: session=<<VALUE>>; Path=/; Max-Age=3600; HttpOnly; Secure; SameSite=Lax
+Set-Cookie: old=<<VALUE>>; Path=/app; Max-Age=0
+
+ok
+`, string(b))
+
+	resp := httptest.NewRecorder()
+	http.SetCookie(resp, &http.Cookie{Name: "id", Value: "1", Domain: "example.com"})
+	assert.Equal(t, "Set-Cookie: id=1; Domain=example.com\n", golden.CookieTranscript(resp.Result(), nil))
+}
+
+func TestRequest_Retry(t *testing.T) {
+	t.Chdir(t.TempDir())
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if calls < 3 {
+			http.Error(w, "starting", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Retry:          golden.RetryPolicy{Attempts: 3, Backoff: time.Millisecond, StatusCodes: []int{http.StatusServiceUnavailable}},
+	}
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("echo"))
+	require.NoError(t, err)
+	mt := &mockT{name: "TestRetry"}
+	resp, ok := fh.Request(mt, http.DefaultClient, req, http.StatusOK)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+	assert.Contains(t, mt.logs, "returned status code 503, retrying in 1ms")
+	assert.Contains(t, mt.logs, "returned status code 503, retrying in 2ms")
+	b, err := os.ReadFile("testdata/TestRetry/TestRetry.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "echo", string(b))
+
+	calls = 0
+	fh.Retry.Attempts = 1
+	mt = &mockT{name: "TestRetry"}
+	_, ok = fh.Request(mt, http.DefaultClient, req, http.StatusOK)
+	assert.False(t, ok)
+	assert.Equal(t, 2, calls)
+	assert.Contains(t, mt.msg, "expected status code 200, got 503")
+}
+
+type flakyClient struct {
+	failures int
+	calls    int
+}
+
+func (c *flakyClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	if c.calls <= c.failures {
+		return nil, errors.New("connection refused")
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("ready"))}, nil
+}
+
+func TestRequest_RetryTransportError(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Retry:          golden.RetryPolicy{Attempts: 2, Backoff: time.Millisecond},
+	}
+	req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1:1", nil)
+	require.NoError(t, err)
+
+	client := &flakyClient{failures: 2}
+	mt := &mockT{name: "TestRetry"}
+	_, ok := fh.Request(mt, client, req, http.StatusOK)
+	assert.True(t, ok)
+	assert.Equal(t, 3, client.calls)
+	assert.Equal(t, 2, strings.Count(mt.logs, "failed, retrying in"))
+}
+
+func TestRetry_OtherRequests(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Retry:          golden.RetryPolicy{Attempts: 1, Backoff: time.Millisecond},
+	}
+	newRequest := func() *http.Request {
+		req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1:1", nil)
+		require.NoError(t, err)
+		return req
+	}
+
+	client := &flakyClient{failures: 1}
+	fh.GraphQL(&mockT{name: "TestGraphQL"}, client, "http://127.0.0.1:1", "{ ready }", nil)
+	assert.Equal(t, 2, client.calls)
+
+	client = &flakyClient{failures: 1}
+	fh.RequestSSE(&mockT{name: "TestSSE"}, client, newRequest(), http.StatusOK, golden.SSEOptions{})
+	assert.Equal(t, 2, client.calls)
+
+	client = &flakyClient{failures: 1}
+	fh.RequestStream(&mockT{name: "TestStream"}, client, newRequest(), http.StatusOK, golden.StreamOptions{})
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestRenderHeaders(t *testing.T) {
+	header := http.Header{
+		"x-request-id": {"1"},
+		"Vary":         {"Accept", "Origin"},
+		"Set-Cookie":   {"a=1", "b=2"},
+		"Date":         {"Mon, 02 Jan 2006 15:04:05 GMT"},
+	}
+	assert.Equal(t, "Set-Cookie: a=1\nSet-Cookie: b=2\nVary: Accept\nVary: Origin\nX-Request-Id: 1\n",
+		golden.RenderHeaders(header, golden.HeaderOptions{Exclude: []string{"date"}}))
+	assert.Equal(t, "Set-Cookie: a=1\nSet-Cookie: b=2\nVary: Accept, Origin\n",
+		golden.RenderHeaders(header, golden.HeaderOptions{Include: []string{"vary", "set-cookie"}, JoinValues: true}))
+
+	duplicates := http.Header{"x-id": {"2"}, "X-Id": {"1"}, "X-ID": {"3"}}
+	for range 20 {
+		assert.Equal(t, "X-Id: 3\nX-Id: 1\nX-Id: 2\n", golden.RenderHeaders(duplicates, golden.HeaderOptions{}))
+	}
+}
+
+func TestHandler_RecordHeaders(t *testing.T) {
+	t.Chdir(t.TempDir())
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write([]byte("body\n"))
+	})
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		RecordHeaders:  &golden.HeaderOptions{Exclude: []string{"Date"}},
+	}
+
+	_, ok := fh.Handler(&mockT{name: "TestHeaders"}, mux, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusOK)
+	assert.True(t, ok)
+	b, err := os.ReadFile("testdata/TestHeaders/TestHeaders.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "Cache-Control: no-store\nContent-Type: text/plain\n\nbody\n", string(b))
+}
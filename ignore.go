@@ -0,0 +1,40 @@
+package golden
+
+import "strings"
+
+// IgnoreLine is the default marker for FileHandler.IgnoreLineMarker.
+const IgnoreLine = "<<IGNORE-LINE>>"
+
+// ignoreLines replaces the golden file lines containing the marker with the actual lines at the same positions,
+// so that they match any actual line. The content is returned as is when the number of lines differ.
+func ignoreLines(expected, actual, marker string) string {
+	return mergeLines(expected, actual, marker, true)
+}
+
+// keepIgnoredLines keeps the marked lines of the old golden file when it's recreated with the actual data,
+// so that recreating doesn't drop the markers. The actual data is returned as is when the number of lines differ.
+func keepIgnoredLines(old, actual, marker string) string {
+	return mergeLines(actual, old, marker, false)
+}
+
+// mergeLines takes the lines from other where the line of base (or of other when markedInBase is false) contains the marker.
+func mergeLines(base, other, marker string, markedInBase bool) string {
+	if !strings.Contains(base, marker) && !strings.Contains(other, marker) {
+		return base
+	}
+
+	baseLines, otherLines := strings.Split(base, "\n"), strings.Split(other, "\n")
+	if len(baseLines) != len(otherLines) {
+		return base
+	}
+	for i := range baseLines {
+		marked := otherLines[i]
+		if markedInBase {
+			marked = baseLines[i]
+		}
+		if strings.Contains(marked, marker) {
+			baseLines[i] = otherLines[i]
+		}
+	}
+	return strings.Join(baseLines, "\n")
+}
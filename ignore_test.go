@@ -0,0 +1,60 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreLineMarker(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestIgnore", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestIgnore/TestIgnore.golden", []byte("starting\n<<IGNORE-LINE>> took\ndone\n"), 0o600))
+
+	fh := &golden.FileHandler{
+		FileName:         golden.TestNameToFilePath,
+		ShouldRecreate:   func(golden.T) bool { return false },
+		Equal:            golden.EqualWithDiff,
+		IgnoreLineMarker: golden.IgnoreLine,
+	}
+
+	mt := &mockT{name: "TestIgnore"}
+	assert.True(t, fh.Assert(mt, "starting\ntook 12ms\ndone\n"))
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestIgnore"}
+	assert.False(t, fh.Assert(mt, "starting\ntook 12ms\nfailed\n"))
+	assert.Contains(t, mt.msg, "+failed")
+
+	mt = &mockT{name: "TestIgnore"}
+	assert.False(t, fh.Assert(mt, "starting\ndone\n"), "marker matches only a line at the same position")
+
+	fh.ShouldRecreate = func(golden.T) bool { return true }
+	assert.True(t, fh.Assert(&mockT{name: "TestIgnore"}, "starting!\ntook 15ms\ndone\n"))
+	b, err := os.ReadFile("testdata/TestIgnore/TestIgnore.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "starting!\n<<IGNORE-LINE>> took\ndone\n", string(b), "marked lines are kept when recreating")
+}
+
+func TestIgnoreLineMarker_TemplateData(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestIgnore", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestIgnore/TestIgnore.golden", []byte("port {{ .Port }}\n<<IGNORE-LINE>> took\ndone\n"), 0o600))
+
+	fh := (&golden.FileHandler{
+		FileName:         golden.TestNameToFilePath,
+		ShouldRecreate:   func(golden.T) bool { return true },
+		Equal:            golden.EqualWithDiff,
+		IgnoreLineMarker: golden.IgnoreLine,
+	}).WithTemplateData(map[string]any{"Port": 8080})
+
+	mt := &mockT{name: "TestIgnore"}
+	assert.True(t, fh.Assert(mt, "port 8080\ntook 15ms\ndone!\n"))
+	assert.False(t, mt.failed, mt.msg)
+	b, err := os.ReadFile("testdata/TestIgnore/TestIgnore.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "port {{ .Port }}\n<<IGNORE-LINE>> took\ndone!\n", string(b), "marked lines are kept with the template data")
+}
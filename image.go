@@ -0,0 +1,158 @@
+package golden
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"time"
+)
+
+// ImageOptions configures the image comparison done by AssertImage.
+type ImageOptions struct {
+	// PixelTolerance is the maximum difference of any color channel (0-255) for the pixels to be considered equal.
+	PixelTolerance uint8
+	// MaxDiffPixels is the number of pixels allowed to exceed the PixelTolerance.
+	MaxDiffPixels int
+
+	// Hash enables perceptual hash comparison instead of the pixel by pixel comparison when set, e.g. DHash or PHash.
+	// It tolerates small differences like antialiasing between different font rendering stacks.
+	Hash func(image.Image) uint64
+	// MaxHashDistance is the maximum Hamming distance between the perceptual hashes for the images to be considered equal.
+	MaxHashDistance int
+}
+
+// AssertImage stores the image as PNG golden file and compares it pixel by pixel against the golden image,
+// see FileHandler.Image for configuring the tolerances. The golden file path is resolved with ImageFileName.
+// On mismatch the actual image and a visual diff image, which highlights the differing pixels in red,
+// are written next to the golden file.
+func AssertImage(t T, img image.Image) bool {
+	return Default().AssertImage(t, img)
+}
+
+// AssertImageBytes decodes the image in any registered format and asserts it using AssertImage.
+func AssertImageBytes(t T, data []byte) bool {
+	return Default().AssertImageBytes(t, data)
+}
+
+func (h *FileHandler) AssertImageBytes(t T, data []byte) bool {
+	t.Helper()
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		NoError(t, err, "failed to decode actual image")
+		return false
+	}
+	return h.AssertImage(t, img)
+}
+
+func (h *FileHandler) AssertImage(t T, img image.Image) bool {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	NoError(t, png.Encode(buf, img), "failed to encode image")
+
+	fileName := ImageFileName(h.FileName(t))
+	// the PNG is stored as is, the text transformations of the golden files would corrupt it
+	expectedData, recreated := h.binaryHandler().loadAndSaveFile(t, fileName, buf.String())
+	res := Result{GoldenPath: fileName, WasRecreated: recreated, GoldenSize: len(expectedData)}
+	defer func() {
+		for _, r := range h.Recorders {
+			r.RecordResult(t.Name(), res)
+		}
+	}()
+	expected, err := png.Decode(strings.NewReader(expectedData))
+	if err != nil {
+		NoError(t, err, "failed to decode golden image")
+		return false
+	}
+
+	start := time.Now()
+	n, diff := CompareImages(expected, img, h.Image.PixelTolerance)
+	reason := fmt.Sprintf("%d pixels exceed the tolerance %d, allowed %d", n, h.Image.PixelTolerance, h.Image.MaxDiffPixels)
+	if h.Image.Hash != nil {
+		distance := HashDistance(h.Image.Hash(expected), h.Image.Hash(img))
+		res.Matched = distance <= h.Image.MaxHashDistance
+		reason = fmt.Sprintf("perceptual hash distance %d exceeds %d", distance, h.Image.MaxHashDistance)
+	} else {
+		res.Matched = n <= h.Image.MaxDiffPixels
+	}
+	res.Duration = time.Since(start)
+	if res.Matched {
+		return true
+	}
+
+	base := strings.TrimSuffix(fileName, ".png")
+	actualName, diffName := base+".actual.png", base+".diff.png"
+	h.writeArtifact(t, actualName, buf.Bytes())
+	diffBuf := &bytes.Buffer{}
+	NoError(t, png.Encode(diffBuf, diff), "failed to encode diff image")
+	h.writeArtifact(t, diffName, diffBuf.Bytes())
+
+	res.Diff = fmt.Sprintf("images differ: %s\nexpected size: %v, actual size: %v\nactual: %s\ndiff:   %s",
+		reason, expected.Bounds().Size(), img.Bounds().Size(), actualName, diffName)
+	defer h.mismatch(t, Mismatch{GoldenFile: fileName, Expected: h.cloneMapped(expectedData), Actual: buf.String(), DiffFile: diffName})
+	t.Errorf("%s\n%s", res.Diff, failureHint(t, fileName, len(expectedData)))
+	return false
+}
+
+// binaryHandler returns a copy of the handler which stores the golden file content as is, without templating,
+// ignored lines, migrations and byte order marks, e.g. for the PNG golden files of AssertImage.
+func (h *FileHandler) binaryHandler() *FileHandler {
+	c := *h
+	c.TemplateData, c.IgnoreLineMarker, c.Migrate, c.BOM = nil, "", nil, BOMKeep
+	return &c
+}
+
+// ImageFileName converts golden file name into image golden file name by replacing the .golden extension with .png.
+func ImageFileName(fileName string) string {
+	return strings.TrimSuffix(fileName, ".golden") + ".png"
+}
+
+var diffColor = color.NRGBA{R: 255, A: 255}
+
+// CompareImages compares the images pixel by pixel and returns the number of pixels where any color channel
+// differs more than the tolerance. The returned diff image shows the differing pixels in red on top of faded expected image.
+// Pixels outside of the other image's bounds are counted as different.
+func CompareImages(expected, actual image.Image, tolerance uint8) (int, *image.NRGBA) {
+	eb, ab := expected.Bounds(), actual.Bounds()
+	bounds := image.Rect(0, 0, max(eb.Dx(), ab.Dx()), max(eb.Dy(), ab.Dy()))
+	diff := image.NewNRGBA(bounds)
+
+	n := 0
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			ep, ap := image.Pt(eb.Min.X+x, eb.Min.Y+y), image.Pt(ab.Min.X+x, ab.Min.Y+y)
+			if !ep.In(eb) || !ap.In(ab) {
+				n++
+				diff.SetNRGBA(x, y, diffColor)
+				continue
+			}
+
+			ec := color.NRGBAModel.Convert(expected.At(ep.X, ep.Y)).(color.NRGBA)
+			ac := color.NRGBAModel.Convert(actual.At(ap.X, ap.Y)).(color.NRGBA)
+			if channelDiff(ec, ac) > tolerance {
+				n++
+				diff.SetNRGBA(x, y, diffColor)
+				continue
+			}
+
+			// Equal pixels are rendered as faded grayscale, so the differences stand out while keeping the context visible.
+			gray := 255 - uint8((255-(float64(ec.R)*0.299+float64(ec.G)*0.587+float64(ec.B)*0.114))/4)
+			diff.SetNRGBA(x, y, color.NRGBA{R: gray, G: gray, B: gray, A: 255})
+		}
+	}
+	return n, diff
+}
+
+func channelDiff(a, b color.NRGBA) uint8 {
+	d := uint8(0)
+	for _, c := range [][2]uint8{{a.R, b.R}, {a.G, b.G}, {a.B, b.B}, {a.A, b.A}} {
+		if c[0] > c[1] {
+			d = max(d, c[0]-c[1])
+		} else {
+			d = max(d, c[1]-c[0])
+		}
+	}
+	return d
+}
@@ -0,0 +1,108 @@
+package golden_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertImage(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestImage"), "failed to remove testdata") })
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}
+
+	img := testImage(color.NRGBA{R: 100, G: 100, B: 100, A: 255})
+	mt := &mockT{name: "TestImage"}
+	assert.True(t, fh.AssertImage(mt, img))
+	assert.False(t, mt.failed)
+	assert.FileExists(t, "./testdata/TestImage/TestImage.png")
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, img))
+	mt = &mockT{name: "TestImage"}
+	assert.True(t, fh.AssertImageBytes(mt, buf.Bytes()))
+	assert.False(t, mt.failed)
+
+	changed := testImage(color.NRGBA{R: 100, G: 100, B: 100, A: 255})
+	changed.SetNRGBA(1, 1, color.NRGBA{R: 105, G: 100, B: 100, A: 255})
+	changed.SetNRGBA(2, 2, color.NRGBA{R: 200, G: 100, B: 100, A: 255})
+
+	mt = &mockT{name: "TestImage"}
+	assert.False(t, fh.AssertImage(mt, changed))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "images differ: 2 pixels exceed the tolerance 0, allowed 0")
+	assert.FileExists(t, "./testdata/TestImage/TestImage.actual.png")
+	assert.FileExists(t, "./testdata/TestImage/TestImage.diff.png")
+
+	fh.Image = golden.ImageOptions{PixelTolerance: 5, MaxDiffPixels: 1}
+	mt = &mockT{name: "TestImage"}
+	assert.True(t, fh.AssertImage(mt, changed))
+	assert.False(t, mt.failed)
+}
+
+func TestCompareImages(t *testing.T) {
+	expected := testImage(color.NRGBA{A: 255})
+	actual := image.NewNRGBA(image.Rect(0, 0, 4, 5))
+	n, diff := golden.CompareImages(expected, actual, 0)
+	assert.Equal(t, 4*5, n, "all pixels differ because of alpha and size")
+	assert.Equal(t, image.Rect(0, 0, 4, 5), diff.Bounds())
+}
+
+func TestAssertImageBytes_Invalid(t *testing.T) {
+	mt := &mockT{name: "TestImageInvalid"}
+	assert.False(t, golden.AssertImageBytes(mt, []byte("not an image")))
+	assert.Contains(t, mt.msg, "failed to decode actual image")
+}
+
+func testImage(c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestAssertImage_Binary(t *testing.T) {
+	t.Chdir(t.TempDir())
+	recorder := &resultRecorder{results: map[string]golden.Result{}}
+	fh := &golden.FileHandler{
+		FileName:         golden.TestNameToFilePath,
+		ShouldRecreate:   func(golden.T) bool { return true },
+		Equal:            golden.EqualWithDiff,
+		TemplateData:     map[string]any{"Port": "P"},
+		IgnoreLineMarker: "IHDR",
+		BOM:              golden.BOMAdd,
+		Recorders:        []golden.Recorder{recorder},
+	}
+
+	img := testImage(color.NRGBA{R: 80, G: 80, B: 80, A: 255})
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, img))
+	mt := &mockT{name: "TestImage"}
+	assert.True(t, fh.AssertImage(mt, img))
+	assert.False(t, mt.failed, mt.msg)
+	assertFileContent(t, "testdata/TestImage/TestImage.png", buf.String())
+	assert.True(t, recorder.results["TestImage"].WasRecreated)
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt = &mockT{name: "TestImage"}
+	assert.False(t, fh.AssertImage(mt, testImage(color.NRGBA{A: 255})))
+	res := recorder.results["TestImage"]
+	assert.False(t, res.Matched)
+	assert.Equal(t, "testdata/TestImage/TestImage.png", res.GoldenPath)
+	assert.Equal(t, buf.Len(), res.GoldenSize)
+	assert.Contains(t, res.Diff, "images differ:")
+}
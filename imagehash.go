@@ -0,0 +1,94 @@
+package golden
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// HashDistance returns the Hamming distance between two perceptual hashes.
+func HashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// DHash computes the difference hash of the image: the image is scaled down to 9x8 grayscale
+// and each bit tells whether a pixel is brighter than its right neighbour.
+func DHash(img image.Image) uint64 {
+	g := grayscale(img, 9, 8)
+	var h uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			h <<= 1
+			if g[y][x] > g[y][x+1] {
+				h |= 1
+			}
+		}
+	}
+	return h
+}
+
+// PHash computes the perceptual hash of the image: the image is scaled down to 32x32 grayscale,
+// transformed with DCT and each bit tells whether the low frequency coefficient is above the median.
+// It's more robust against small changes than DHash but slower to compute.
+func PHash(img image.Image) uint64 {
+	const size, low = 32, 8
+	g := grayscale(img, size, size)
+
+	coeffs := make([]float64, 0, low*low)
+	for u := 0; u < low; u++ {
+		for v := 0; v < low; v++ {
+			sum := 0.0
+			for y := 0; y < size; y++ {
+				for x := 0; x < size; x++ {
+					sum += g[y][x] *
+						math.Cos(float64(2*y+1)*float64(u)*math.Pi/(2*size)) *
+						math.Cos(float64(2*x+1)*float64(v)*math.Pi/(2*size))
+				}
+			}
+			coeffs = append(coeffs, sum)
+		}
+	}
+
+	// The DC coefficient describes the average brightness only, so it's left out from the median.
+	sorted := append([]float64(nil), coeffs[1:]...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+
+	var h uint64
+	for _, c := range coeffs {
+		h <<= 1
+		if c > median {
+			h |= 1
+		}
+	}
+	return h
+}
+
+// grayscale scales the image to w x h luminance values by averaging the source pixels covered by each target pixel.
+func grayscale(img image.Image, w, h int) [][]float64 {
+	b := img.Bounds()
+	out := make([][]float64, h)
+	for ty := 0; ty < h; ty++ {
+		out[ty] = make([]float64, w)
+		y0, y1 := b.Min.Y+ty*b.Dy()/h, b.Min.Y+(ty+1)*b.Dy()/h
+		y1 = max(y1, y0+1)
+		for tx := 0; tx < w; tx++ {
+			x0, x1 := b.Min.X+tx*b.Dx()/w, b.Min.X+(tx+1)*b.Dx()/w
+			x1 = max(x1, x0+1)
+
+			sum, n := 0.0, 0
+			for y := y0; y < y1 && y < b.Max.Y; y++ {
+				for x := x0; x < x1 && x < b.Max.X; x++ {
+					sum += float64(color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y)
+					n++
+				}
+			}
+			if n > 0 {
+				out[ty][tx] = sum / float64(n)
+			}
+		}
+	}
+	return out
+}
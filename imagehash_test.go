@@ -0,0 +1,66 @@
+package golden_test
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerceptualHash(t *testing.T) {
+	img := patternImage(64, 64, 0, false)
+	noisy := patternImage(64, 64, 3, false)
+	inverted := patternImage(64, 64, 0, true)
+
+	for name, hash := range map[string]func(image.Image) uint64{"dhash": golden.DHash, "phash": golden.PHash} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, hash(img), hash(img))
+			assert.LessOrEqual(t, golden.HashDistance(hash(img), hash(noisy)), 4)
+			assert.Greater(t, golden.HashDistance(hash(img), hash(inverted)), 20)
+		})
+	}
+}
+
+func TestAssertImage_Hash(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestImageHash"), "failed to remove testdata") })
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Image:          golden.ImageOptions{Hash: golden.DHash, MaxHashDistance: 4},
+	}
+
+	mt := &mockT{name: "TestImageHash"}
+	assert.True(t, fh.AssertImage(mt, patternImage(64, 64, 0, false)))
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt = &mockT{name: "TestImageHash"}
+	assert.True(t, fh.AssertImage(mt, patternImage(64, 64, 3, false)), "small noise is tolerated")
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestImageHash"}
+	assert.False(t, fh.AssertImage(mt, patternImage(64, 64, 0, true)))
+	assert.Contains(t, mt.msg, "perceptual hash distance")
+}
+
+// patternImage returns blocks of varying brightness with deterministic noise of the given amplitude.
+func patternImage(w, h int, noise int, invert bool) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := ((x/8)*37 + (y/8)*91 + (x/8)*(y/8)*13) % 256
+			if noise > 0 {
+				v += (x*7+y*13)%(2*noise+1) - noise
+			}
+			v = min(max(v, 0), 255)
+			if invert {
+				v = 255 - v
+			}
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(v), G: uint8(v), B: uint8(v), A: 255})
+		}
+	}
+	return img
+}
@@ -0,0 +1,221 @@
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// AssertInline checks the data against the expected value stored as a string literal in the test source,
+// which is easier to review than a separate golden file for small snapshots:
+//
+//	want := `{"id": 1}`
+//	golden.AssertInline(t, got, &want)
+//
+// The want variable must be declared in the calling function and initialized with a string literal.
+// When recreating, the literal is rewritten in the test source file with the actual data and want is updated.
+func AssertInline(t T, data string, want *string) bool {
+	return Default().AssertInline(t, data, want)
+}
+
+func (h *FileHandler) AssertInline(t T, data string, want *string) bool {
+	t.Helper()
+	if h.NoOp {
+		return true
+	}
+	if h.ProcessContent != nil {
+		data = h.ProcessContent(t, data)
+	}
+
+	// The test source is kept intact when regenerating for VerifyClean, so the stale snapshots fail instead.
+	if h.ShouldRecreate(t) && os.Getenv(envVerifyOutputDir) == "" {
+		file, line, ok := inlineCaller()
+		if !ok {
+			t.Errorf("failed to locate the AssertInline call")
+			t.FailNow()
+			return false
+		}
+		if h.FailOnRecreate {
+			t.Errorf("inline snapshot %s:%d would be recreated but recreating is disabled in read-only mode, "+
+				"run the tests locally with GOLDEN_FILES_RECREATE=true and commit the changes", file, line)
+			t.FailNow()
+			return false
+		}
+
+		t.Logf("recreating inline snapshot: %s:%d", file, line)
+		NoError(t, inlineSnapshots.rewrite(file, line, data), "failed to rewrite inline snapshot")
+		*want = data
+	}
+	return h.Equal(t, *want, data, "inline snapshot mismatch\nupdate with: GOLDEN_FILES_RECREATE=true go test -run '"+runPattern(t)+"' .")
+}
+
+// inlineCaller returns the location of the AssertInline call in the test code.
+func inlineCaller() (string, int, bool) {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "github.com/go-tstr/golden.") {
+			return f.File, f.Line, f.File != ""
+		}
+		if !more {
+			return "", 0, false
+		}
+	}
+}
+
+var inlineSnapshots = &inlineRewriter{files: map[string]*inlineFile{}}
+
+// inlineRewriter rewrites the inline snapshot literals. The positions are resolved against the original source
+// read before the first rewrite, so the rewrites of the same file don't shift the lines of the other calls.
+type inlineRewriter struct {
+	mu    sync.Mutex
+	files map[string]*inlineFile
+}
+
+type inlineFile struct {
+	src   []byte
+	fset  *token.FileSet
+	ast   *ast.File
+	edits map[int]inlineEdit
+}
+
+type inlineEdit struct {
+	end  int
+	text string
+}
+
+func (r *inlineRewriter) rewrite(file string, line int, data string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, ok := r.files[file]
+	if !ok {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		fset := token.NewFileSet()
+		parsed, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		f = &inlineFile{src: src, fset: fset, ast: parsed, edits: map[int]inlineEdit{}}
+		r.files[file] = f
+	}
+
+	lit, err := f.literal(line)
+	if err != nil {
+		return fmt.Errorf("%s:%d: %w", file, line, err)
+	}
+	start, end := f.fset.Position(lit.Pos()).Offset, f.fset.Position(lit.End()).Offset
+	f.edits[start] = inlineEdit{end: end, text: inlineLiteral(data)}
+	return os.WriteFile(file, f.apply(), 0o600)
+}
+
+// literal finds the string literal initializing the want variable of the AssertInline call on the given line.
+func (f *inlineFile) literal(line int) (*ast.BasicLit, error) {
+	var call *ast.CallExpr
+	var scope ast.Node
+	ast.Inspect(f.ast, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			if f.fset.Position(n.Pos()).Line <= line && f.fset.Position(n.End()).Line >= line {
+				scope = n
+			}
+		case *ast.CallExpr:
+			if call == nil && f.fset.Position(n.Pos()).Line <= line && f.fset.Position(n.End()).Line >= line && isInlineCall(n) {
+				call = n
+			}
+		}
+		return true
+	})
+	if call == nil || len(call.Args) != 3 {
+		return nil, errors.New("AssertInline call not found")
+	}
+
+	var name *ast.Ident
+	if ref, ok := call.Args[2].(*ast.UnaryExpr); ok && ref.Op == token.AND {
+		name, _ = ref.X.(*ast.Ident)
+	}
+	if name == nil || scope == nil {
+		return nil, errors.New("AssertInline want must be a pointer to a local variable, e.g. &want")
+	}
+
+	var lit *ast.BasicLit
+	ast.Inspect(scope, func(n ast.Node) bool {
+		if n == nil || n.Pos() >= call.Pos() {
+			return n == nil
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && id.Name == name.Name && i < len(n.Rhs) {
+					lit = stringLit(n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			for i, id := range n.Names {
+				if id.Name == name.Name && i < len(n.Values) {
+					lit = stringLit(n.Values[i])
+				}
+			}
+		}
+		return true
+	})
+	if lit == nil {
+		return nil, fmt.Errorf("%s must be initialized with a string literal before the AssertInline call", name.Name)
+	}
+	return lit, nil
+}
+
+// apply returns the original source with the rewritten literals.
+func (f *inlineFile) apply() []byte {
+	starts := make([]int, 0, len(f.edits))
+	for start := range f.edits {
+		starts = append(starts, start)
+	}
+	sort.Ints(starts)
+
+	var out []byte
+	prev := 0
+	for _, start := range starts {
+		out = append(out, f.src[prev:start]...)
+		out = append(out, f.edits[start].text...)
+		prev = f.edits[start].end
+	}
+	return append(out, f.src[prev:]...)
+}
+
+func isInlineCall(call *ast.CallExpr) bool {
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		return fn.Sel.Name == "AssertInline"
+	case *ast.Ident:
+		return fn.Name == "AssertInline"
+	}
+	return false
+}
+
+func stringLit(e ast.Expr) *ast.BasicLit {
+	if lit, ok := e.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		return lit
+	}
+	return nil
+}
+
+// inlineLiteral renders the data as raw string literal when possible, otherwise as interpreted string literal.
+func inlineLiteral(data string) string {
+	if strconv.CanBackquote(strings.ReplaceAll(data, "\n", "")) {
+		return "`" + data + "`"
+	}
+	return strconv.Quote(data)
+}
@@ -0,0 +1,69 @@
+package golden_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertInline(t *testing.T) {
+	want := `{"id": 1}`
+	mt := &mockT{name: "TestAssertInline"}
+	assert.True(t, golden.AssertInline(mt, `{"id": 1}`, &want))
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestAssertInline"}
+	assert.False(t, golden.AssertInline(mt, `{"id": 2}`, &want))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "inline snapshot mismatch")
+	assert.Contains(t, mt.msg, "update with: GOLDEN_FILES_RECREATE=true go test -run '^TestAssertInline$' .")
+}
+
+func TestAssertInline_Recreate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test in a temporary module")
+	}
+
+	// The snapshots are rewritten in the source of the calling test, so the fixture test runs in a copy.
+	root, err := os.Getwd()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	src, err := os.ReadFile("testdata/inline/snapshot_test.go")
+	require.NoError(t, err)
+	sum, err := os.ReadFile("go.sum")
+	require.NoError(t, err)
+	mod := "module example.com/inline\n\ngo 1.24.2\n\nrequire github.com/go-tstr/golden v0.0.0\n\nreplace github.com/go-tstr/golden => " + root + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(mod), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), sum, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "snapshot_test.go"), src, 0o600))
+
+	goTest := func(run string) (string, error) {
+		cmd := exec.Command("go", "test", "-mod=mod", "-count=1", "-run", run, ".")
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOLDEN_FILES_RECREATE=true")
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+
+	out, err := goTest("^TestSnapshot$")
+	require.NoError(t, err, out)
+	b, err := os.ReadFile(filepath.Join(dir, "snapshot_test.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "\tfirst := `new\nvalue`\n\tsecond := \"with `backquote`\"\n")
+
+	out, err = goTest("^TestMissingLiteral$")
+	require.Error(t, err)
+	assert.Contains(t, out, "missing must be initialized with a string literal before the AssertInline call")
+
+	out, err = goTest("^TestVerifying$")
+	require.Error(t, err, "stale snapshot fails when regenerating for VerifyClean")
+	assert.Contains(t, out, "inline snapshot mismatch")
+	b, err = os.ReadFile(filepath.Join(dir, "snapshot_test.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "\twant := \"old\"\n", "the source isn't rewritten when regenerating for VerifyClean")
+}
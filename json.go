@@ -0,0 +1,82 @@
+package golden
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/tidwall/pretty"
+)
+
+// PrettyJSON formats the JSON string using the pretty package.
+// This is useful for making the JSON more readable in the golden file
+// also provides cleaner diffs when comparing JSON files.
+// The JSON is formatted as text without decoding it into Go values, so numbers are kept exactly as written,
+// e.g. 64-bit IDs and high precision decimals aren't rounded through float64.
+func PrettyJSON(t T, data string) string {
+	return string(pretty.Pretty([]byte(data)))
+}
+
+// JSONOptions configures the JSON formatting done by JSONProcessor and PrettyJSONStream.
+type JSONOptions struct {
+	// Indent is the number of spaces used for indentation, defaults to 2.
+	Indent int
+	// SortKeys sorts the object keys alphabetically.
+	SortKeys bool
+	// EscapeHTML escapes <, > and & characters inside the strings like encoding/json does by default.
+	EscapeHTML bool
+}
+
+// JSONProcessor returns ProcessContent function which formats the JSON using the given options.
+// Streams of multiple JSON values, e.g. NDJSON, are formatted value by value, see PrettyJSONStream.
+func JSONProcessor(opts JSONOptions) func(T, string) string {
+	return func(t T, data string) string {
+		sb := &strings.Builder{}
+		NoError(t, PrettyJSONStream(sb, strings.NewReader(data), opts), "failed to format JSON")
+		return sb.String()
+	}
+}
+
+// PrettyJSONStream reads a stream of JSON values, e.g. NDJSON, and writes each of them formatted to w followed by a newline.
+// Only a single value is held in memory at a time, so it can be used for formatting huge streams.
+// Values are never decoded into Go types, so numbers keep their original precision and large integers aren't rounded.
+func PrettyJSONStream(w io.Writer, r io.Reader, opts JSONOptions) error {
+	indent := opts.Indent
+	if indent <= 0 {
+		indent = 2
+	}
+
+	prettyOpts := &pretty.Options{
+		Width:    pretty.DefaultOptions.Width,
+		Indent:   strings.Repeat(" ", indent),
+		SortKeys: opts.SortKeys,
+	}
+
+	bw := bufio.NewWriter(w)
+	dec := json.NewDecoder(r)
+	escaped := &bytes.Buffer{}
+	for {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		formatted := pretty.PrettyOptions(raw, prettyOpts)
+		if opts.EscapeHTML {
+			escaped.Reset()
+			json.HTMLEscape(escaped, formatted)
+			formatted = escaped.Bytes()
+		}
+		if _, err := bw.Write(formatted); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,71 @@
+package golden_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONProcessor(t *testing.T) {
+	const data = `{"name": "<someone>", "id": 12345678901234567890, "price": 1.100000000000000000001, "tags": ["a","b"], "address": {"zip": "00100", "city": "Helsinki"}}`
+
+	tests := []struct {
+		name string
+		opts golden.JSONOptions
+	}{
+		{name: "default", opts: golden.JSONOptions{}},
+		{name: "sort keys", opts: golden.JSONOptions{SortKeys: true}},
+		{name: "indent", opts: golden.JSONOptions{Indent: 4}},
+		{name: "escape html", opts: golden.JSONOptions{EscapeHTML: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			golden.Assert(t, golden.JSONProcessor(tt.opts)(t, data))
+		})
+	}
+}
+
+func TestJSONProcessor_NDJSON(t *testing.T) {
+	mt := &mockT{name: "TestNDJSON"}
+	got := golden.JSONProcessor(golden.JSONOptions{})(mt, "{\"id\":1}\n{\"id\":9007199254740993}\n")
+	assert.Equal(t, "{\n  \"id\": 1\n}\n{\n  \"id\": 9007199254740993\n}\n", got)
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestNDJSON"}
+	golden.JSONProcessor(golden.JSONOptions{})(mt, "{\"id\":1}\n{\"id\":")
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "failed to format JSON")
+}
+
+func TestPrettyJSONStream(t *testing.T) {
+	const n = 10000
+	r := strings.NewReader(strings.Repeat("{\"id\":1234567890123456789}\n", n))
+	sb := &strings.Builder{}
+	require.NoError(t, golden.PrettyJSONStream(sb, r, golden.JSONOptions{Indent: 1}))
+	assert.Equal(t, strings.Repeat("{\n \"id\": 1234567890123456789\n}\n", n), sb.String())
+}
+
+func TestJSONNumberPrecision(t *testing.T) {
+	const data = `{"id": 9007199254740993, "big": 123456789012345678901234567890, "decimal": 0.10000000000000000555, "exp": 1e400, "neg": -0.0}`
+
+	processors := map[string]func(golden.T, string) string{
+		"PrettyJSON":        golden.PrettyJSON,
+		"JSONProcessor":     golden.JSONProcessor(golden.JSONOptions{}),
+		"JSONProcessorSort": golden.JSONProcessor(golden.JSONOptions{SortKeys: true, EscapeHTML: true}),
+	}
+
+	for name, process := range processors {
+		t.Run(name, func(t *testing.T) {
+			mt := &mockT{name: "TestJSONNumberPrecision"}
+			got := process(mt, data)
+			assert.False(t, mt.failed)
+			for _, n := range []string{"9007199254740993", "123456789012345678901234567890", "0.10000000000000000555", "1e400", "-0.0"} {
+				assert.Contains(t, got, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package golden
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GlyphRun is a run of text placed by a text layout engine, e.g. a line or a word of a rendered paragraph.
+type GlyphRun struct {
+	Text string
+	Font string
+	Size float64
+	// Bounds is the bounding box of the run in the layout's coordinate space.
+	Bounds Rect
+}
+
+// Rect is a rectangle with the origin at its top left corner.
+type Rect struct {
+	X, Y, Width, Height float64
+}
+
+// LayoutOptions configures the rendering of the layout snapshots in AssertLayout.
+type LayoutOptions struct {
+	// Tolerance is the grid the coordinates and sizes are rounded to, defaults to 1.
+	// Use larger values when the font hinting differs more between the platforms.
+	Tolerance float64
+}
+
+// AssertLayout checks the golden file content against the layout snapshot rendered by RenderLayout
+// using the handler's Layout options. Unlike comparing rasterized output, the snapshot is portable
+// across platforms with different font rendering.
+func AssertLayout(t T, runs []GlyphRun) bool {
+	return Default().AssertLayout(t, runs)
+}
+
+func (h *FileHandler) AssertLayout(t T, runs []GlyphRun) bool {
+	t.Helper()
+	return h.Assert(t, RenderLayout(runs, h.Layout))
+}
+
+// RenderLayout renders the glyph runs in the given order, one per line, e.g.
+//
+//	"Hello world" Inter 12 at 10,20 size 64x14
+//
+// Positions, sizes and the font size are rounded to the Tolerance.
+func RenderLayout(runs []GlyphRun, opts LayoutOptions) string {
+	tolerance := opts.Tolerance
+	if tolerance <= 0 {
+		tolerance = 1
+	}
+	// the multiples of the tolerance are formatted with its number of decimals, e.g. 0.3 instead of 0.30000000000000004
+	decimals := 0
+	if _, frac, ok := strings.Cut(strconv.FormatFloat(tolerance, 'f', -1, 64), "."); ok {
+		decimals = len(frac)
+	}
+	round := func(v float64) string {
+		v = math.Round(v/tolerance) * tolerance
+		if v == 0 {
+			v = 0
+		}
+		s := strconv.FormatFloat(v, 'f', decimals, 64)
+		if decimals > 0 {
+			s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+		if s == "-0" {
+			s = "0"
+		}
+		return s
+	}
+
+	sb := &strings.Builder{}
+	for _, r := range runs {
+		fmt.Fprintf(sb, "%s %s %s at %s,%s size %sx%s\n",
+			strconv.Quote(r.Text), r.Font, round(r.Size),
+			round(r.Bounds.X), round(r.Bounds.Y), round(r.Bounds.Width), round(r.Bounds.Height))
+	}
+	return sb.String()
+}
@@ -0,0 +1,29 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertLayout(t *testing.T) {
+	golden.AssertLayout(t, []golden.GlyphRun{
+		{Text: "Hello", Font: "Inter", Size: 12, Bounds: golden.Rect{X: 10.2, Y: 19.8, Width: 31.4, Height: 14.1}},
+		{Text: `"world"`, Font: "Inter Bold", Size: 12, Bounds: golden.Rect{X: 44.9, Y: 19.8, Width: 38.6, Height: 14.1}},
+	})
+}
+
+func TestRenderLayout(t *testing.T) {
+	linux := []golden.GlyphRun{{Text: "Total", Font: "DejaVu", Size: 11, Bounds: golden.Rect{X: 0.4, Y: -0.3, Width: 31.2, Height: 13.4}}}
+	mac := []golden.GlyphRun{{Text: "Total", Font: "DejaVu", Size: 11, Bounds: golden.Rect{X: 0.9, Y: 0.2, Width: 32.4, Height: 14.2}}}
+
+	opts := golden.LayoutOptions{Tolerance: 2}
+	assert.Equal(t, "\"Total\" DejaVu 12 at 0,0 size 32x14\n", golden.RenderLayout(linux, opts))
+	assert.Equal(t, golden.RenderLayout(linux, opts), golden.RenderLayout(mac, opts))
+	assert.NotEqual(t, golden.RenderLayout(linux, golden.LayoutOptions{}), golden.RenderLayout(mac, golden.LayoutOptions{}))
+
+	fractional := []golden.GlyphRun{{Text: "x", Font: "Inter", Size: 10.04, Bounds: golden.Rect{X: 0.31, Y: -0.04, Width: 2.26, Height: 0.7}}}
+	assert.Equal(t, "\"x\" Inter 10 at 0.3,0 size 2.3x0.7\n", golden.RenderLayout(fractional, golden.LayoutOptions{Tolerance: 0.1}))
+	assert.Equal(t, "\"x\" Inter 10 at 0.25,0 size 2.25x0.75\n", golden.RenderLayout(fractional, golden.LayoutOptions{Tolerance: 0.25}))
+}
@@ -0,0 +1,81 @@
+package golden
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// lfsPointerPrefix starts the pointer files which Git LFS stores in place of the content until it's pulled.
+const lfsPointerPrefix = "version https://git-lfs.github.com/spec/v1\n"
+
+// IsLFSPointer reports whether the content is a Git LFS pointer file instead of the actual content,
+// which happens when the repository is cloned without Git LFS installed or the objects weren't pulled.
+func IsLFSPointer(data []byte) bool {
+	return len(data) < 1024 && strings.HasPrefix(string(data), lfsPointerPrefix)
+}
+
+// LFSStorage stores the golden files of at least MinSize bytes under Dir, which is intended to be tracked by Git LFS,
+// and the smaller ones as is. The path of the large file is the golden file path joined to Dir,
+// e.g. testdata/TestX/TestX.golden is stored as lfs/testdata/TestX/TestX.golden when Dir is "lfs".
+// Track the directory in .gitattributes:
+//
+//	lfs/** filter=lfs diff=lfs merge=lfs -text
+//
+// Moving the file between the locations removes the stale one, so the underlying storage must implement
+// Remove(name string) error like OSStorage does.
+type LFSStorage struct {
+	// Storage stores the files, the OS filesystem is used when nil.
+	Storage Storage
+	// Dir is the Git LFS tracked directory.
+	Dir string
+	// MinSize is the size in bytes from which the golden files are stored under Dir.
+	MinSize int
+}
+
+// ReadFile reads the file, or the one under Dir when it doesn't exist.
+func (s LFSStorage) ReadFile(name string) ([]byte, error) {
+	b, err := s.storage().ReadFile(name)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return b, err
+	}
+
+	b, lfsErr := s.storage().ReadFile(s.lfsName(name))
+	if errors.Is(lfsErr, fs.ErrNotExist) {
+		return nil, err
+	}
+	return b, lfsErr
+}
+
+// WriteFile writes the file under Dir when the data is at least MinSize bytes and removes the stale file.
+func (s LFSStorage) WriteFile(name string, data []byte) error {
+	target, stale := name, s.lfsName(name)
+	if len(data) >= s.MinSize {
+		target, stale = stale, target
+	}
+
+	if err := s.storage().WriteFile(target, data); err != nil {
+		return err
+	}
+	return removeStale(s.storage(), stale)
+}
+
+// Remove removes the file from both locations.
+func (s LFSStorage) Remove(name string) error {
+	if err := removeStale(s.storage(), name); err != nil {
+		return err
+	}
+	return removeStale(s.storage(), s.lfsName(name))
+}
+
+func (s LFSStorage) lfsName(name string) string {
+	return filepath.Join(s.Dir, name)
+}
+
+func (s LFSStorage) storage() Storage {
+	if s.Storage == nil {
+		return OSStorage{}
+	}
+	return s.Storage
+}
@@ -0,0 +1,51 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lfsPointer = `version https://git-lfs.github.com/spec/v1
+oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393
+size 12345
+`
+
+func TestLFSPointer(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestLFS", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestLFS/TestLFS.golden", []byte(lfsPointer), 0o600))
+
+	mt := &mockT{name: "TestLFS"}
+	assert.False(t, golden.Assert(mt, "data"))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "golden file testdata/TestLFS/TestLFS.golden is a Git LFS pointer, its content wasn't downloaded, install Git LFS and run: git lfs pull")
+	assert.False(t, golden.IsLFSPointer([]byte("data")))
+}
+
+func TestLFSStorage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Storage:        golden.LFSStorage{Dir: "lfs", MinSize: 8},
+	}
+
+	assert.True(t, fh.Assert(&mockT{name: "TestLFS"}, "large content"))
+	assert.FileExists(t, "lfs/testdata/TestLFS/TestLFS.golden")
+	assert.NoFileExists(t, "testdata/TestLFS/TestLFS.golden")
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt := &mockT{name: "TestLFS"}
+	assert.True(t, fh.Assert(mt, "large content"))
+	assert.False(t, mt.failed)
+
+	fh.ShouldRecreate = func(golden.T) bool { return true }
+	assert.True(t, fh.Assert(&mockT{name: "TestLFS"}, "small"))
+	assert.FileExists(t, "testdata/TestLFS/TestLFS.golden")
+	assert.NoFileExists(t, "lfs/testdata/TestLFS/TestLFS.golden")
+}
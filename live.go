@@ -0,0 +1,283 @@
+package golden
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// LiveRequest is a Request assertion which can be re-executed against a live deployment, see VerifyLive.
+type LiveRequest struct {
+	Test string `json:"test"`
+	// GoldenFile is relative to the module root, or absolute when the module root wasn't found.
+	GoldenFile string      `json:"golden_file"`
+	Method     string      `json:"method"`
+	Path       string      `json:"path"`
+	Query      string      `json:"query,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	// Body is recorded when the request supports GetBody, e.g. created with http.NewRequest with in-memory body.
+	Body       string `json:"body,omitempty"`
+	StatusCode int    `json:"status_code"`
+}
+
+// liveSecretHeaders aren't recorded since the recordings are committed, credentials for the live environment are given to VerifyLive.
+var liveSecretHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// LiveRecorder collects the Request assertions of the handlers marked as live-verifiable with FileHandler.Live.
+// It's used the same way as RequestRecorder, the written file is given to the golden live command:
+//
+//	go run github.com/go-tstr/golden/cmd/golden live -base https://staging.example.com live.jsonl
+//
+// The command reports drift between the live responses and the committed golden files,
+// it can be scheduled in CI for monitoring that the deployed API still honors the contract.
+type LiveRecorder struct {
+	mu       sync.Mutex
+	requests []LiveRequest
+}
+
+// Live returns a copy of the handler which records its Request assertions into the recorder.
+// VerifyLive compares the live responses against the golden files as they are stored in the OS filesystem,
+// so the Request assertions of handlers whose golden files aren't the response bodies, i.e. with Scrubbers,
+// HashOnly, TemplateData, transcripts or other Storage than OSStorage, fail instead of being recorded.
+func (h *FileHandler) Live(recorder *LiveRecorder) *FileHandler {
+	c := *h
+	c.LiveRecorder = recorder
+	return &c
+}
+
+// Record stores the request.
+func (r *LiveRecorder) Record(req LiveRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+}
+
+// Requests returns copy of the stored requests.
+func (r *LiveRecorder) Requests() []LiveRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]LiveRequest(nil), r.requests...)
+}
+
+// WriteFile appends the requests to the given file as JSON lines, so multiple test packages can record into the same file.
+func (r *LiveRecorder) WriteFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, req := range r.Requests() {
+		if err := enc.Encode(req); err != nil {
+			return errors.Join(err, f.Close())
+		}
+	}
+	return f.Close()
+}
+
+// ReadLiveRequests reads the requests written by LiveRecorder.WriteFile.
+func ReadLiveRequests(path string) ([]LiveRequest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var requests []LiveRequest
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 16<<20)
+	for s.Scan() {
+		if len(strings.TrimSpace(s.Text())) == 0 {
+			continue
+		}
+
+		var req LiveRequest
+		if err := json.Unmarshal(s.Bytes(), &req); err != nil {
+			return nil, fmt.Errorf("invalid live request %q: %w", s.Text(), err)
+		}
+		requests = append(requests, req)
+	}
+	return requests, s.Err()
+}
+
+// recordLive records the request, the body is read from a copy returned by GetBody, so the sent body is left intact,
+// the requests without GetBody are recorded without the body.
+func (h *FileHandler) recordLive(t T, req *http.Request, statusCode int) {
+	if reason := h.liveUnsupported(); reason != "" {
+		t.Errorf("request %s %s can't be verified live: the golden file isn't the response body, handler has %s set",
+			req.Method, req.URL.Path, reason)
+		return
+	}
+
+	body := ""
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(rc)
+			body = string(b)
+		}
+	}
+
+	header := req.Header.Clone()
+	for _, name := range liveSecretHeaders {
+		header.Del(name)
+	}
+	if len(header) == 0 {
+		header = nil
+	}
+
+	goldenFile, err := filepath.Abs(h.FileName(t))
+	NoError(t, err, "failed to resolve golden file path")
+	if root, err := FindModuleRoot(); err == nil {
+		if rel, err := filepath.Rel(root, goldenFile); err == nil {
+			goldenFile = filepath.ToSlash(rel)
+		}
+	}
+
+	h.LiveRecorder.Record(LiveRequest{
+		Test:       t.Name(),
+		GoldenFile: goldenFile,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Query:      req.URL.RawQuery,
+		Header:     header,
+		Body:       body,
+		StatusCode: statusCode,
+	})
+}
+
+// liveUnsupported returns the option which makes the golden file differ from the response body.
+func (h *FileHandler) liveUnsupported() string {
+	switch {
+	case len(h.Scrubbers) > 0:
+		return "Scrubbers"
+	case h.HashOnly:
+		return "HashOnly"
+	case len(h.TemplateData) > 0:
+		return "TemplateData"
+	case h.RecordRedirects:
+		return "RecordRedirects"
+	case h.RecordHeaders != nil:
+		return "RecordHeaders"
+	case h.RecordCookies:
+		return "RecordCookies"
+	}
+	if _, ok := h.Storage.(OSStorage); h.Storage != nil && !ok {
+		return "Storage"
+	}
+	return ""
+}
+
+// LiveDrift describes a difference between the live response and the committed golden file.
+type LiveDrift struct {
+	Request    LiveRequest
+	StatusCode int
+	// Diff is the unified diff between the golden file and the live response body, empty when the bodies match.
+	Diff string
+	// Err is set when the request couldn't be executed or the golden file read.
+	Err error
+}
+
+// VerifyLive re-executes the requests against the baseURL and returns the drift between the responses
+// and the golden files found under root. The header is added to every request, e.g. credentials for the environment.
+// Bodies which are valid JSON are compared semantically since the golden files are usually formatted by ProcessContent,
+// the numbers are compared by their text, so no precision is lost. Other bodies must match exactly.
+func VerifyLive(client Client, baseURL string, header http.Header, root string, requests []LiveRequest) []LiveDrift {
+	var drift []LiveDrift
+	for _, lr := range requests {
+		d := verifyLive(client, baseURL, header, root, lr)
+		if d.Err != nil || d.Diff != "" || d.StatusCode != lr.StatusCode {
+			drift = append(drift, d)
+		}
+	}
+	return drift
+}
+
+func verifyLive(client Client, baseURL string, header http.Header, root string, lr LiveRequest) LiveDrift {
+	d := LiveDrift{Request: lr}
+	goldenFile := lr.GoldenFile
+	if !filepath.IsAbs(goldenFile) {
+		goldenFile = filepath.Join(root, filepath.FromSlash(goldenFile))
+	}
+	expected, err := os.ReadFile(goldenFile)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+
+	url := strings.TrimSuffix(baseURL, "/") + lr.Path
+	if lr.Query != "" {
+		url += "?" + lr.Query
+	}
+	req, err := http.NewRequest(lr.Method, url, strings.NewReader(lr.Body))
+	if err != nil {
+		d.Err = err
+		return d
+	}
+	for name, values := range lr.Header {
+		req.Header[name] = values
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+	defer resp.Body.Close()
+	d.StatusCode = resp.StatusCode
+
+	actual, err := io.ReadAll(resp.Body)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+
+	if jsonEqual(expected, actual) || bytes.Equal(expected, actual) {
+		return d
+	}
+	exp, act := string(expected), string(actual)
+	if json.Valid(expected) && json.Valid(actual) {
+		exp, act = PrettyJSON(nil, exp), PrettyJSON(nil, act)
+	}
+	d.Diff = UnifiedDiff(lr.GoldenFile, "live", exp, act)
+	return d
+}
+
+// jsonEqual compares the JSON values with the numbers decoded as json.Number, so large integers which differ
+// only in the digits lost by float64 aren't equal.
+func jsonEqual(a, b []byte) bool {
+	va, errA := decodeJSONNumbers(a)
+	vb, errB := decodeJSONNumbers(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func decodeJSONNumbers(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("trailing data after JSON value")
+	}
+	return v, nil
+}
@@ -0,0 +1,95 @@
+package golden_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLive(t *testing.T) {
+	t.Chdir(t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"created":true}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	recorder := &golden.LiveRecorder{}
+	fh := (&golden.FileHandler{
+		FileName:         golden.TestNameToFilePath,
+		ShouldRecreate:   func(golden.T) bool { return true },
+		Equal:            golden.EqualWithDiff,
+		ProcessResponses: true,
+	}).Live(recorder)
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/users?dry_run=1", strings.NewReader(`{"name":"someone"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Request-Source", "test")
+	_, ok := fh.Request(&mockT{name: "TestLive"}, http.DefaultClient, req, http.StatusOK)
+	require.True(t, ok)
+
+	requests := recorder.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, `{"name":"someone"}`, requests[0].Body)
+	assert.Equal(t, "dry_run=1", requests[0].Query)
+	assert.Empty(t, requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "test", requests[0].Header.Get("X-Request-Source"))
+
+	path := filepath.Join(t.TempDir(), "live.jsonl")
+	require.NoError(t, recorder.WriteFile(path))
+	read, err := golden.ReadLiveRequests(path)
+	require.NoError(t, err)
+	assert.Equal(t, requests, read)
+
+	// Formatting done by ProcessContent isn't drift.
+	root, err := filepath.Abs(".")
+	require.NoError(t, err)
+	read[0].GoldenFile = filepath.Join(root, "testdata", "TestLive", "TestLive.golden")
+	assert.Empty(t, golden.VerifyLive(http.DefaultClient, srv.URL, nil, root, read))
+}
+
+func TestVerifyLive_LargeIntegers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":9007199254740993}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	root := t.TempDir()
+	require.NoError(t, golden.OSStorage{}.WriteFile(filepath.Join(root, "id.golden"), []byte("{\n  \"id\": 9007199254740992\n}\n")))
+	drift := golden.VerifyLive(http.DefaultClient, srv.URL, nil, root, []golden.LiveRequest{
+		{Test: "TestID", GoldenFile: "id.golden", Method: http.MethodGet, Path: "/id", StatusCode: http.StatusOK},
+	})
+	require.Len(t, drift, 1, "integers beyond float64 precision are compared exactly")
+	assert.Contains(t, drift[0].Diff, "+  \"id\": 9007199254740993")
+}
+
+func TestLive_Unsupported(t *testing.T) {
+	t.Chdir(t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`ok`))
+	}))
+	t.Cleanup(srv.Close)
+
+	recorder := &golden.LiveRecorder{}
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		HashOnly:       true,
+	}).Live(recorder)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/status", nil)
+	require.NoError(t, err)
+	mt := &mockT{name: "TestLive"}
+	fh.Request(mt, http.DefaultClient, req, http.StatusOK)
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "request GET /status can't be verified live: the golden file isn't the response body, handler has HashOnly set")
+	assert.Empty(t, recorder.Requests())
+}
@@ -0,0 +1,76 @@
+package golden
+
+import (
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Logs captures the structured log output written by fn to the logger and checks it against the golden file.
+// The logger writes slog text format at debug level without the time attribute, so the output is stable between the runs:
+//
+//	golden.Logs(t, func(log *slog.Logger) {
+//		svc := NewService(log)
+//		svc.Start()
+//	})
+func Logs(t T, fn func(*slog.Logger)) bool {
+	return Default().Logs(t, fn)
+}
+
+func (h *FileHandler) Logs(t T, fn func(*slog.Logger)) bool {
+	t.Helper()
+	sb := &strings.Builder{}
+	fn(slog.New(slog.NewTextHandler(sb, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+	return h.Assert(t, sb.String())
+}
+
+// LogWriter captures the log output written by fn to the writer, e.g. by log.Logger or slog handler configured by the code
+// under test, normalizes it with NormalizeLogs and checks it against the golden file.
+func LogWriter(t T, fn func(w io.Writer)) bool {
+	return Default().LogWriter(t, fn)
+}
+
+func (h *FileHandler) LogWriter(t T, fn func(w io.Writer)) bool {
+	t.Helper()
+	sb := &strings.Builder{}
+	fn(sb)
+	return h.Assert(t, NormalizeLogs(h.Placeholder)(t, sb.String()))
+}
+
+var (
+	stdLogTimestamp = regexp.MustCompile(`(?m)^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}(\.\d+)?`)
+	logLevel        = regexp.MustCompile(`(?i)(\blevel=|"level":\s*")(debug|info|warn|warning|error)\b`)
+)
+
+// NormalizeLogs returns ProcessContent function which makes the log output stable and uniform:
+// log package and RFC 3339 timestamps are replaced with TIMESTAMP placeholder rendered with the style
+// and the levels of slog text and JSON formats are upper cased, e.g. level=info becomes level=INFO.
+// AngleBrackets is used when style is nil.
+func NormalizeLogs(style PlaceholderStyle) func(T, string) string {
+	if style == nil {
+		style = AngleBrackets
+	}
+
+	scrub := Scrub(style, TimestampScrubber)
+	return func(t T, data string) string {
+		data = stdLogTimestamp.ReplaceAllLiteralString(data, style(TimestampScrubber.Name))
+		data = scrub(t, data)
+		return logLevel.ReplaceAllStringFunc(data, func(s string) string {
+			m := logLevel.FindStringSubmatch(s)
+			level := strings.ToUpper(m[2])
+			if level == "WARNING" {
+				level = "WARN"
+			}
+			return m[1] + level
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package golden_test
+
+import (
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogs(t *testing.T) {
+	golden.Logs(t, func(log *slog.Logger) {
+		log.Debug("starting", "port", 8080)
+		log.WithGroup("db").Info("connected", "host", "localhost")
+		log.Warn("slow query", "took", "1s")
+	})
+}
+
+func TestLogWriter(t *testing.T) {
+	golden.LogWriter(t, func(w io.Writer) {
+		log.New(w, "", log.LstdFlags|log.Lmicroseconds).Println("plain log line")
+		slog.New(slog.NewJSONHandler(w, nil)).Error("json log line", "id", 1)
+		_, _ = io.WriteString(w, "time=2024-01-02T03:04:05.123Z level=warning msg=\"custom\"\n")
+	})
+}
+
+func TestNormalizeLogs(t *testing.T) {
+	normalize := golden.NormalizeLogs(golden.SquareBrackets)
+	assert.Equal(t, "[TIMESTAMP] level=INFO msg=x\n", normalize(t, "2024/01/02 03:04:05 level=info msg=x\n"))
+	assert.Equal(t, `{"time":"[TIMESTAMP]","level":"DEBUG"}`, normalize(t, `{"time":"2024-01-02T03:04:05+02:00","level":"debug"}`))
+}
@@ -0,0 +1,99 @@
+package golden
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// AssertMarkdown renders the Markdown source with the handler's RenderMarkdown and checks the golden file content
+// against the HTML output formatted with PrettyHTML, e.g. for documentation generators:
+//
+//	h := golden.DefaultHandler.WithMarkdownRenderer(func(src []byte) ([]byte, error) {
+//		var buf bytes.Buffer
+//		err := goldmark.Convert(src, &buf)
+//		return buf.Bytes(), err
+//	})
+//	h.AssertMarkdown(t, readme)
+func AssertMarkdown(t T, src string) bool {
+	return Default().AssertMarkdown(t, src)
+}
+
+func (h *FileHandler) AssertMarkdown(t T, src string) bool {
+	t.Helper()
+	if h.RenderMarkdown == nil {
+		NoError(t, errors.New("RenderMarkdown isn't set, see WithMarkdownRenderer"), "failed to render Markdown")
+		return false
+	}
+
+	rendered, err := h.RenderMarkdown([]byte(src))
+	if err != nil {
+		NoError(t, err, "failed to render Markdown")
+		return false
+	}
+	return h.Assert(t, PrettyHTML(t, string(rendered)))
+}
+
+// WithMarkdownRenderer returns a copy of the handler which renders the Markdown of AssertMarkdown with the renderer.
+func (h *FileHandler) WithMarkdownRenderer(render func(src []byte) ([]byte, error)) *FileHandler {
+	c := *h
+	c.RenderMarkdown = render
+	return &c
+}
+
+var markdownReference = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:\s*\S`)
+
+// NormalizeMarkdown makes the generated Markdown stable: the trailing whitespace is removed, trailing double space
+// hard line breaks are rewritten as backslash hard line breaks, and the reference link definitions are moved
+// to the end of the document sorted by their labels. The content of the fenced code blocks is kept as is.
+func NormalizeMarkdown(_ T, data string) string {
+	var lines, refs []string
+	breaks := map[int]bool{}
+	fence := ""
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")) {
+			fence = trimmed[:3]
+			lines = append(lines, strings.TrimRight(line, " \t"))
+			continue
+		}
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+				line = strings.TrimRight(line, " \t")
+			}
+			lines = append(lines, line)
+			continue
+		}
+
+		if markdownReference.MatchString(line) {
+			refs = append(refs, strings.TrimSpace(line))
+			continue
+		}
+		if strings.HasSuffix(line, "  ") && strings.TrimSpace(line) != "" {
+			breaks[len(lines)] = true
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+
+	for i := range breaks {
+		// The hard line break at the end of a paragraph has no effect.
+		if i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+			lines[i] += `\`
+		}
+	}
+
+	content := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if len(refs) > 0 {
+		sort.SliceStable(refs, func(i, j int) bool {
+			return markdownLabel(refs[i]) < markdownLabel(refs[j])
+		})
+		content += "\n\n" + strings.Join(refs, "\n")
+	}
+	return content + "\n"
+}
+
+func markdownLabel(ref string) string {
+	return strings.ToLower(markdownReference.FindStringSubmatch(ref)[1])
+}
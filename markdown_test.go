@@ -0,0 +1,83 @@
+package golden_test
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeMarkdown(t *testing.T) {
+	src := "# Title  \n\nSee [docs][b] and [api][A].\n[b]: https://example.com/b\n\nfirst line  \nsecond line  \n\n" +
+		"```go\nfmt.Println(\"x\")  \n[c]: not a reference\n```\n\n [A]: https://example.com/a \"API\"\n\n\n"
+	assert.Equal(t, "# Title\n\nSee [docs][b] and [api][A].\n\nfirst line\\\nsecond line\n\n"+
+		"```go\nfmt.Println(\"x\")  \n[c]: not a reference\n```\n\n"+
+		"[A]: https://example.com/a \"API\"\n[b]: https://example.com/b\n",
+		golden.NormalizeMarkdown(&mockT{}, src))
+}
+
+// renderParagraphs is a minimal Markdown renderer for the tests rendering fenced code blocks like goldmark, real code plugs in e.g. goldmark.
+func renderParagraphs(src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, errors.New("empty document")
+	}
+	sb := &strings.Builder{}
+	for _, p := range strings.Split(strings.TrimSpace(string(src)), "\n\n") {
+		if code, ok := strings.CutPrefix(p, "```"); ok {
+			lang, code, _ := strings.Cut(strings.TrimSuffix(code, "```"), "\n")
+			sb.WriteString(`<pre><code class="language-` + lang + `">` + html.EscapeString(code) + "</code></pre>\n")
+			continue
+		}
+		if title, ok := strings.CutPrefix(p, "# "); ok {
+			sb.WriteString("<h1>" + html.EscapeString(title) + "</h1>")
+			continue
+		}
+		sb.WriteString("<p>" + html.EscapeString(p) + "</p>")
+	}
+	return []byte(sb.String()), nil
+}
+
+func TestAssertMarkdown(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}).WithMarkdownRenderer(renderParagraphs)
+
+	mt := &mockT{name: "TestMarkdown"}
+	assert.True(t, fh.AssertMarkdown(mt, "# Title\n\nTom & Jerry\n"))
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	assert.True(t, fh.AssertMarkdown(mt, "# Title\n\n\nTom & Jerry"))
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestMarkdown"}
+	assert.False(t, fh.AssertMarkdown(mt, ""))
+	assert.Contains(t, mt.msg, "failed to render Markdown: empty document")
+
+	mt = &mockT{name: "TestMarkdown"}
+	fh.RenderMarkdown = nil
+	assert.False(t, fh.AssertMarkdown(mt, "# Title"))
+	assert.Contains(t, mt.msg, "RenderMarkdown isn't set")
+}
+
+func TestAssertMarkdown_CodeBlock(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}).WithMarkdownRenderer(renderParagraphs)
+
+	mt := &mockT{name: "TestMarkdown"}
+	assert.True(t, fh.AssertMarkdown(mt, "# Usage\n\n```go\nfunc main() {\n\tfmt.Println(\"a < b\")\n}\n```\n"))
+	assertFileContent(t, "testdata/TestMarkdown/TestMarkdown.golden", "<h1>Usage</h1>\n"+
+		"<pre><code class=\"language-go\">func main() {\n\tfmt.Println(&#34;a &lt; b&#34;)\n}\n</code></pre>\n")
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	assert.False(t, fh.AssertMarkdown(mt, "# Usage\n\n```go\nfunc main() {\n\tfmt.Println(\"a > b\")\n}\n```\n"))
+	assert.Contains(t, mt.msg, "+\tfmt.Println(&#34;a &gt; b&#34;)")
+}
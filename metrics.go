@@ -0,0 +1,153 @@
+package golden
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics collects the statistics of the assertions of the whole run: the number of assertions, the total size of
+// the golden files read, the slowest comparisons and the largest golden files, which helps finding fixture bloat
+// and slow tests. Metrics is a Recorder, it's safe for concurrent use and it's intended to be set up in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		metrics := &golden.Metrics{}
+//		golden.DefaultHandler.Recorders = append(golden.DefaultHandler.Recorders, metrics)
+//
+//		code := m.Run()
+//		metrics.Print(os.Stdout)
+//		os.Exit(code)
+//	}
+type Metrics struct {
+	// Top is the number of the slowest comparisons and the largest golden files reported, 10 when zero.
+	Top int
+
+	mu      sync.Mutex
+	entries []ReportEntry
+}
+
+// MetricsSummary is the summary of the collected statistics rendered by Metrics.
+type MetricsSummary struct {
+	Assertions int `json:"assertions"`
+	// GoldenBytes is the total size of the golden file content read by the assertions.
+	GoldenBytes int64          `json:"golden_bytes"`
+	Slowest     []MetricsEntry `json:"slowest"`
+	Largest     []MetricsEntry `json:"largest"`
+}
+
+// MetricsEntry is an assertion in MetricsSummary.
+type MetricsEntry struct {
+	Test       string        `json:"test"`
+	GoldenFile string        `json:"golden_file"`
+	Size       int           `json:"size"`
+	Duration   time.Duration `json:"duration_ns"`
+}
+
+// RecordResult stores the result of the assertion.
+func (m *Metrics) RecordResult(test string, res Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, ReportEntry{Test: test, Result: res})
+}
+
+// Summary returns the statistics of the recorded assertions. The largest golden files are listed once,
+// even when several assertions read them.
+func (m *Metrics) Summary() MetricsSummary {
+	m.mu.Lock()
+	entries := make([]MetricsEntry, 0, len(m.entries))
+	for _, e := range m.entries {
+		entries = append(entries, MetricsEntry{Test: e.Test, GoldenFile: e.Result.GoldenPath, Size: e.Result.GoldenSize, Duration: e.Result.Duration})
+	}
+	m.mu.Unlock()
+
+	top := m.Top
+	if top <= 0 {
+		top = 10
+	}
+
+	s := MetricsSummary{Assertions: len(entries), Slowest: []MetricsEntry{}, Largest: []MetricsEntry{}}
+	for _, e := range entries {
+		s.GoldenBytes += int64(e.Size)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Duration > entries[j].Duration
+	})
+	s.Slowest = append(s.Slowest, entries[:min(top, len(entries))]...)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Size != entries[j].Size {
+			return entries[i].Size > entries[j].Size
+		}
+		return entries[i].GoldenFile < entries[j].GoldenFile
+	})
+	seen := map[string]bool{}
+	for _, e := range entries {
+		if len(s.Largest) == top {
+			break
+		}
+		if !seen[e.GoldenFile] {
+			seen[e.GoldenFile] = true
+			s.Largest = append(s.Largest, e)
+		}
+	}
+	return s
+}
+
+// Print writes the summary as text.
+func (m *Metrics) Print(w io.Writer) error {
+	s := m.Summary()
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "golden: %d assertions read %s of golden files\n", s.Assertions, formatBytes(s.GoldenBytes))
+	if len(s.Slowest) > 0 {
+		sb.WriteString("slowest comparisons:\n")
+		for _, e := range s.Slowest {
+			fmt.Fprintf(sb, "  %10s  %s (%s)\n", e.Duration.Round(time.Microsecond), e.Test, e.GoldenFile)
+		}
+	}
+	if len(s.Largest) > 0 {
+		sb.WriteString("largest golden files:\n")
+		for _, e := range s.Largest {
+			fmt.Fprintf(sb, "  %10s  %s\n", formatBytes(int64(e.Size)), e.GoldenFile)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteFile writes the summary, files with .json extension are written as JSON, see MetricsSummary,
+// and the others as text, see Print.
+func (m *Metrics) WriteFile(path string) error {
+	sb := &strings.Builder{}
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		b, err := json.MarshalIndent(m.Summary(), "", "  ")
+		if err != nil {
+			return err
+		}
+		sb.Write(append(b, '\n'))
+	} else if err := m.Print(sb); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0o600)
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
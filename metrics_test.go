@@ -0,0 +1,59 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	m := &golden.Metrics{Top: 2}
+	m.RecordResult("TestA", golden.Result{GoldenPath: "testdata/a.golden", GoldenSize: 100, Duration: time.Millisecond})
+	m.RecordResult("TestA/again", golden.Result{GoldenPath: "testdata/a.golden", GoldenSize: 100, Duration: 3 * time.Millisecond})
+	m.RecordResult("TestB", golden.Result{GoldenPath: "testdata/b.golden", GoldenSize: 3 << 20, Duration: 2 * time.Millisecond})
+	m.RecordResult("TestC", golden.Result{GoldenPath: "testdata/c.golden", GoldenSize: 10})
+
+	path := filepath.Join(t.TempDir(), "metrics.txt")
+	require.NoError(t, m.WriteFile(path))
+	assertFileContent(t, path, `golden: 4 assertions read 3.0 MiB of golden files
+slowest comparisons:
+         3ms  TestA/again (testdata/a.golden)
+         2ms  TestB (testdata/b.golden)
+largest golden files:
+     3.0 MiB  testdata/b.golden
+       100 B  testdata/a.golden
+`)
+
+	s := m.Summary()
+	assert.Equal(t, 4, s.Assertions)
+	assert.Equal(t, int64(3<<20+210), s.GoldenBytes)
+
+	path = filepath.Join(t.TempDir(), "metrics.json")
+	require.NoError(t, m.WriteFile(path))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"golden_bytes": 3145938`)
+	assert.Contains(t, string(b), `"duration_ns": 3000000`)
+}
+
+func TestMetricsRecorder(t *testing.T) {
+	t.Chdir(t.TempDir())
+	m := &golden.Metrics{}
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Recorders:      []golden.Recorder{m},
+	}
+	assert.True(t, fh.Assert(&mockT{name: "TestMetrics"}, "data"))
+
+	s := m.Summary()
+	assert.Equal(t, 1, s.Assertions)
+	assert.Equal(t, int64(4), s.GoldenBytes)
+	assert.Equal(t, []golden.MetricsEntry{{Test: "TestMetrics", GoldenFile: "testdata/TestMetrics/TestMetrics.golden", Size: 4, Duration: s.Largest[0].Duration}}, s.Largest)
+}
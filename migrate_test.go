@@ -0,0 +1,48 @@
+package golden_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestMigrate", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestMigrate/TestMigrate.golden", []byte(`{"id": "[UUID]"}`), 0o600))
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		Migrate: func(old string) (string, bool) {
+			if !strings.Contains(old, "[UUID]") {
+				return old, false
+			}
+			return strings.ReplaceAll(old, "[UUID]", "<uuid>"), true
+		},
+	}
+
+	mt := &mockT{name: "TestMigrate"}
+	assert.True(t, fh.Assert(mt, `{"id": "<uuid>"}`))
+	assert.False(t, mt.failed)
+	b, err := os.ReadFile("testdata/TestMigrate/TestMigrate.golden")
+	require.NoError(t, err)
+	assert.Equal(t, `{"id": "[UUID]"}`, string(b), "golden file is rewritten only with RewriteMigrated")
+
+	fh.RewriteMigrated = true
+	mt = &mockT{name: "TestMigrate"}
+	assert.True(t, fh.Assert(mt, `{"id": "<uuid>"}`))
+	assert.Contains(t, mt.logs, "rewriting migrated golden file: testdata/TestMigrate/TestMigrate.golden")
+	b, err = os.ReadFile("testdata/TestMigrate/TestMigrate.golden")
+	require.NoError(t, err)
+	assert.Equal(t, `{"id": "<uuid>"}`, string(b))
+
+	mt = &mockT{name: "TestMigrate"}
+	assert.True(t, fh.Assert(mt, `{"id": "<uuid>"}`))
+	assert.Empty(t, mt.logs, "already migrated golden file isn't rewritten")
+}
@@ -0,0 +1,33 @@
+package golden
+
+import "strings"
+
+// readGolden reads the golden file like readFile, or memory-maps it when it's at least MmapThreshold bytes.
+// Only the files in the OS filesystem are mapped and only when they weren't just recreated and won't be rewritten
+// with RewriteMigrated, since rewriting a mapped file invalidates the mapping. Reading is used as fallback when the platform doesn't support mmap,
+// mapping fails or T can't register the cleanup. The returned bool tells whether the content is mapped,
+// mapped content must not be retained after the test.
+func (h *FileHandler) readGolden(t T, fileName string, recreated bool) ([]byte, bool, error) {
+	if h.MmapThreshold > 0 && h.FS == nil && !recreated && !h.RewriteMigrated {
+		_, isOS := h.storage().(OSStorage)
+		c, canCleanup := t.(interface{ Cleanup(func()) })
+		if isOS && canCleanup {
+			if b, unmap, err := mmapFile(fileName, h.MmapThreshold); err == nil && unmap != nil {
+				c.Cleanup(func() { NoError(t, unmap(), "failed to unmap golden file") })
+				return b, true, nil
+			}
+		}
+	}
+
+	b, err := h.readFile(fileName, recreated)
+	return b, false, err
+}
+
+// cloneMapped copies the content which can refer to the mapped golden file, so the integrations like Publisher,
+// DiffCommand and Recorders can retain it after the mapping is released in t.Cleanup.
+func (h *FileHandler) cloneMapped(s string) string {
+	if h.MmapThreshold <= 0 {
+		return s
+	}
+	return strings.Clone(s)
+}
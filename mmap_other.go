@@ -0,0 +1,10 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package golden
+
+import "errors"
+
+// mmapFile isn't supported on this platform, the golden files are always read.
+func mmapFile(string, int64) ([]byte, func() error, error) {
+	return nil, nil, errors.New("mmap isn't supported on this platform")
+}
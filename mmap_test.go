@@ -0,0 +1,69 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMmapThreshold(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "large.golden")
+	content := strings.Repeat("line\n", 1000)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	fh := &golden.FileHandler{
+		FileName:       func(golden.T) string { return path },
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		MmapThreshold:  1024,
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		assert.True(t, fh.Assert(t, content))
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		var published golden.Mismatch
+		ph := *fh
+		ph.Publisher = golden.PublisherFunc(func(m golden.Mismatch) (string, error) {
+			published = m
+			return "", nil
+		})
+
+		ct := &cleanupT{mockT: &mockT{name: "TestMmapThreshold/Mismatch"}}
+		res := ph.AssertResult(ct, content+"extra\n")
+		assert.False(t, res.Matched)
+		assert.Contains(t, res.Diff, "+extra")
+		require.Len(t, ct.cleanups, 1, "mapping is released in cleanup")
+		ct.cleanups[0]()
+		assert.NotContains(t, ct.msg, "unmap")
+		assert.Equal(t, content, published.Expected, "published content is readable after the mapping is released")
+	})
+
+	t.Run("RewriteMigrated", func(t *testing.T) {
+		mh := *fh
+		mh.RewriteMigrated = true
+		ct := &cleanupT{mockT: &mockT{name: "TestMmapThreshold/RewriteMigrated"}}
+		assert.True(t, mh.Assert(ct, content))
+		assert.Empty(t, ct.cleanups, "the file which can be rewritten isn't mapped")
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		mt := &mockT{name: "TestMmapThreshold/Fallback"}
+		assert.True(t, fh.Assert(mt, content), "T without Cleanup reads the file")
+		assert.False(t, mt.failed)
+	})
+}
+
+type cleanupT struct {
+	*mockT
+	cleanups []func()
+}
+
+func (c *cleanupT) Cleanup(fn func()) { c.cleanups = append(c.cleanups, fn) }
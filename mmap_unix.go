@@ -0,0 +1,33 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package golden
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps the regular file read-only when it's at least threshold bytes, nil unmap function means
+// that the file is too small and it should be read instead.
+func mmapFile(name string, threshold int64) ([]byte, func() error, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	size := info.Size()
+	if !info.Mode().IsRegular() || size < threshold || int64(int(size)) != size {
+		return nil, nil, nil
+	}
+
+	b, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, func() error { return syscall.Munmap(b) }, nil
+}
@@ -0,0 +1,236 @@
+package golden
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// MoveDiffOptions configures MoveDiff and EqualWithMoves.
+type MoveDiffOptions struct {
+	// MinMoveLines is the number of lines from which a block deleted in one place and inserted in another one
+	// is reported as moved, defaults to 3.
+	MinMoveLines int
+	// MaxBlockLines is the number of lines above which inserted and deleted blocks are shortened to their first
+	// and last lines, defaults to 20.
+	MaxBlockLines int
+	// Context is the number of unchanged lines shown around the changes, defaults to 3, negative value shows
+	// no unchanged lines.
+	Context int
+}
+
+func (o MoveDiffOptions) withDefaults() MoveDiffOptions {
+	if o.MinMoveLines <= 0 {
+		o.MinMoveLines = 3
+	}
+	if o.MaxBlockLines <= 0 {
+		o.MaxBlockLines = 20
+	}
+	if o.Context == 0 {
+		o.Context = 3
+	}
+	if o.Context < 0 {
+		o.Context = 0
+	}
+	return o
+}
+
+// MoveDiffer is a Differ and DiffRenderer reporting the mismatch with MoveDiff, so reordered JSON arrays and
+// reshuffled report sections are shown as moved blocks instead of deleted and inserted lines. Set it with
+// FileHandler.WithDiffer, so Result.Diff and the WriteDiff file use MoveDiff too:
+//
+//	h := golden.DefaultHandler.WithDiffer(golden.MoveDiffer{})
+type MoveDiffer MoveDiffOptions
+
+// Equal compares the strings and reports the mismatch with MoveDiff.
+func (d MoveDiffer) Equal(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if expected == actual {
+		return true
+	}
+
+	msg := "Not equal:\n" + d.Diff("expected", "actual", expected, actual)
+	if len(msgAndArgs) > 0 {
+		msg += "\n" + messageFromMsgAndArgs(msgAndArgs...)
+	}
+	t.Errorf("%s", msg)
+	return false
+}
+
+// Diff returns MoveDiff of the contents.
+func (d MoveDiffer) Diff(expectedName, actualName, expected, actual string) string {
+	return MoveDiff(expectedName, actualName, expected, actual, MoveDiffOptions(d))
+}
+
+// EqualWithMoves returns Equal function which compares the strings and reports the mismatch with MoveDiff,
+// see MoveDiffer, which also renders Result.Diff and the WriteDiff file with MoveDiff.
+func EqualWithMoves(opts MoveDiffOptions) func(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+	return MoveDiffer(opts).Equal
+}
+
+// MoveDiff returns line diff between expected and actual content like UnifiedDiff, which reports the blocks of at least
+// MinMoveLines lines deleted in one place and inserted in another one as a single moved line on both sides:
+//
+//	-[moved 12 lines to actual line 40: "{"]
+//	+[moved 12 lines from expected line 3: "{"]
+//
+// and shortens the blocks longer than MaxBlockLines lines to their first and last lines. The hunk headers contain
+// the line numbers where the hunks start, the line counts are omitted since the hunks are shortened.
+// Empty string is returned when the contents are equal.
+func MoveDiff(expectedName, actualName, expected, actual string, opts MoveDiffOptions) string {
+	if expected == actual {
+		return ""
+	}
+	opts = opts.withDefaults()
+
+	edits := lineEdits(diffLines(expected), diffLines(actual))
+	detectMoves(edits, opts.MinMoveLines)
+	items := renderEdits(edits, opts.MaxBlockLines)
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "--- %s\n+++ %s\n", expectedName, actualName)
+	last := -2
+	for i, it := range items {
+		if it.kind == ' ' && !nearChange(items, i, opts.Context) {
+			continue
+		}
+		if i != last+1 {
+			fmt.Fprintf(sb, "@@ -%d +%d @@\n", it.aLine, it.bLine)
+		}
+		last = i
+		sb.WriteString(string(it.kind) + it.text + "\n")
+	}
+	return sb.String()
+}
+
+func diffLines(s string) []string {
+	lines := splitLines(s)
+	for i, l := range lines {
+		lines[i] = strings.TrimSuffix(l, "\n")
+	}
+	return lines
+}
+
+// lineEdit is a line of the diff, kind is ' ' for unchanged, '-' for deleted and '+' for inserted lines.
+// The line numbers are 1-based, aLine of the inserted line and bLine of the deleted line are the next lines.
+type lineEdit struct {
+	kind         byte
+	text         string
+	aLine, bLine int
+	// moved is the index of the first edit of the other side of the moved block, -1 when the line isn't moved.
+	moved int
+	// moveLen is the number of lines of the moved block, set on its first line.
+	moveLen int
+}
+
+func lineEdits(a, b []string) []lineEdit {
+	var edits []lineEdit
+	m := difflib.NewMatcherWithJunk(a, b, false, nil)
+	for _, op := range m.GetOpCodes() {
+		if op.Tag == 'e' {
+			for k := 0; k < op.I2-op.I1; k++ {
+				edits = append(edits, lineEdit{kind: ' ', text: a[op.I1+k], aLine: op.I1 + k + 1, bLine: op.J1 + k + 1, moved: -1})
+			}
+			continue
+		}
+		for i := op.I1; i < op.I2; i++ {
+			edits = append(edits, lineEdit{kind: '-', text: a[i], aLine: i + 1, bLine: op.J1 + 1, moved: -1})
+		}
+		for j := op.J1; j < op.J2; j++ {
+			edits = append(edits, lineEdit{kind: '+', text: b[j], aLine: op.I2 + 1, bLine: j + 1, moved: -1})
+		}
+	}
+	return edits
+}
+
+// detectMoves pairs the longest runs of deleted lines with the identical runs of inserted lines.
+func detectMoves(edits []lineEdit, minLines int) {
+	inserted := map[string][]int{}
+	for i, e := range edits {
+		if e.kind == '+' {
+			inserted[e.text] = append(inserted[e.text], i)
+		}
+	}
+
+	free := func(i int, kind byte) bool {
+		return i < len(edits) && edits[i].kind == kind && edits[i].moved < 0
+	}
+	for i := 0; i < len(edits); i++ {
+		if !free(i, '-') {
+			continue
+		}
+		best, bestLen := -1, 0
+		for _, j := range inserted[edits[i].text] {
+			n := 0
+			for free(i+n, '-') && free(j+n, '+') && edits[i+n].text == edits[j+n].text {
+				n++
+			}
+			if n > bestLen {
+				best, bestLen = j, n
+			}
+		}
+		if bestLen < minLines {
+			continue
+		}
+		for k := 0; k < bestLen; k++ {
+			edits[i+k].moved, edits[best+k].moved = best, i
+		}
+		edits[i].moveLen, edits[best].moveLen = bestLen, bestLen
+		i += bestLen - 1
+	}
+}
+
+// renderEdits replaces the moved blocks with a single line and shortens the long blocks of deleted and inserted lines.
+func renderEdits(edits []lineEdit, maxBlock int) []lineEdit {
+	var items []lineEdit
+	for i := 0; i < len(edits); {
+		e := edits[i]
+		switch {
+		case e.moved >= 0:
+			other := edits[e.moved]
+			text := fmt.Sprintf("[moved %d lines to actual line %d: %s]", e.moveLen, other.bLine, strconv.Quote(e.text))
+			if e.kind == '+' {
+				text = fmt.Sprintf("[moved %d lines from expected line %d: %s]", e.moveLen, other.aLine, strconv.Quote(e.text))
+			}
+			items = append(items, lineEdit{kind: e.kind, text: text, aLine: e.aLine, bLine: e.bLine})
+			i += e.moveLen
+		case e.kind == ' ':
+			items = append(items, e)
+			i++
+		default:
+			end := i
+			for end < len(edits) && edits[end].kind == e.kind && edits[end].moved < 0 {
+				end++
+			}
+			block := edits[i:end]
+			if len(block) <= maxBlock {
+				items = append(items, block...)
+			} else {
+				head, tail := (maxBlock+1)/2, maxBlock/2
+				verb := "deleted"
+				if e.kind == '+' {
+					verb = "inserted"
+				}
+				items = append(items, block[:head]...)
+				marker := block[head]
+				marker.text = fmt.Sprintf("[... %d more lines %s ...]", len(block)-head-tail, verb)
+				items = append(items, marker)
+				items = append(items, block[len(block)-tail:]...)
+			}
+			i = end
+		}
+	}
+	return items
+}
+
+// nearChange reports whether a changed line is within the context of the unchanged line at the index.
+func nearChange(items []lineEdit, i, context int) bool {
+	for j := max(i-context, 0); j <= min(i+context, len(items)-1); j++ {
+		if items[j].kind != ' ' {
+			return true
+		}
+	}
+	return false
+}
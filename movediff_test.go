@@ -0,0 +1,104 @@
+package golden_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		opts     golden.MoveDiffOptions
+		want     string
+	}{
+		{
+			name:     "moved block",
+			expected: "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n",
+			actual:   "a\ne\nf\ng\nh\nb\nc\nd\ni\nJ\n",
+			want: "--- expected\n+++ actual\n@@ -1 +1 @@\n a\n" +
+				"-[moved 3 lines to actual line 6: \"b\"]\n e\n f\n g\n h\n+[moved 3 lines from expected line 2: \"b\"]\n i\n-j\n+J\n",
+		},
+		{
+			name:     "short blocks aren't moves",
+			expected: "a\nb\nc\nd\n",
+			actual:   "c\nd\na\nb\n",
+			want:     "--- expected\n+++ actual\n@@ -1 +1 @@\n+c\n+d\n a\n b\n-c\n-d\n",
+		},
+		{
+			name:     "large insertion",
+			expected: "x\ny\n",
+			actual:   "x\n" + numberedLines(50) + "y\n",
+			opts:     golden.MoveDiffOptions{MaxBlockLines: 4},
+			want: "--- expected\n+++ actual\n@@ -1 +1 @@\n x\n+line 0\n+line 1\n+[... 46 more lines inserted ...]\n" +
+				"+line 48\n+line 49\n y\n",
+		},
+		{
+			name:     "separate hunks",
+			expected: "0\n" + numberedLines(10) + "1\n",
+			actual:   "changed\n" + numberedLines(10) + "changed\n",
+			want: "--- expected\n+++ actual\n@@ -1 +1 @@\n-0\n+changed\n line 0\n line 1\n line 2\n" +
+				"@@ -9 +9 @@\n line 7\n line 8\n line 9\n-1\n+changed\n",
+		},
+		{
+			name:     "no context",
+			expected: "0\n" + numberedLines(10) + "1\n",
+			actual:   "changed\n" + numberedLines(10) + "changed\n",
+			opts:     golden.MoveDiffOptions{Context: -1},
+			want:     "--- expected\n+++ actual\n@@ -1 +1 @@\n-0\n+changed\n@@ -12 +12 @@\n-1\n+changed\n",
+		},
+		{
+			name:     "equal",
+			expected: "a\n",
+			actual:   "a\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, golden.MoveDiff("expected", "actual", tt.expected, tt.actual, tt.opts))
+		})
+	}
+}
+
+func TestEqualWithMoves(t *testing.T) {
+	equal := golden.EqualWithMoves(golden.MoveDiffOptions{})
+	mt := &mockT{name: "TestEqualWithMoves"}
+	assert.True(t, equal(mt, "a\n", "a\n"))
+	assert.False(t, mt.failed)
+
+	assert.False(t, equal(mt, "a\nb\nc\nd\n", "d\na\nb\nc\n", "golden file %s", "x.golden"))
+	assert.True(t, mt.failed)
+	assert.Equal(t, "\nNot equal:\n--- expected\n+++ actual\n@@ -1 +1 @@\n+d\n a\n b\n c\n-d\n\ngolden file x.golden", mt.msg)
+}
+
+func TestMoveDiffer(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		WriteDiff:      true,
+	}).WithDiffer(golden.MoveDiffer{})
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestMoveDiffer/TestMoveDiffer.golden", []byte("a\nb\nc\nd\n")))
+
+	mt := &mockT{name: "TestMoveDiffer"}
+	res := fh.AssertResult(mt, "d\na\nb\nc\n")
+	assert.False(t, res.Matched)
+	want := "--- testdata/TestMoveDiffer/TestMoveDiffer.golden\n+++ actual\n@@ -1 +1 @@\n+d\n a\n b\n c\n-d\n"
+	assert.Equal(t, want, res.Diff)
+	assertFileContent(t, "testdata/TestMoveDiffer/TestMoveDiffer.golden.diff", want)
+	assert.Contains(t, mt.msg, "Not equal:\n--- expected\n+++ actual\n@@ -1 +1 @@\n+d\n")
+}
+
+func numberedLines(n int) string {
+	sb := &strings.Builder{}
+	for i := range n {
+		fmt.Fprintf(sb, "line %d\n", i)
+	}
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+package golden
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MultipartBoundary replaces the random boundaries of the multipart bodies normalized by PrettyMultipart.
+const MultipartBoundary = "golden-boundary"
+
+var boundaryParam = regexp.MustCompile(`(?i)boundary="?([^";\s]+)"?`)
+
+// PrettyMultipart normalizes the multipart body, e.g. multipart/form-data file upload, for golden comparison.
+// The random boundary is replaced with MultipartBoundary, also in the Content-Type header when the data is
+// a recorded request including the headers. The parts are sorted by the form field name and file name,
+// their headers are sorted by name and the lines are separated with "\n" instead of "\r\n".
+// The boundary is read from the Content-Type header in the data or from the first delimiter line.
+func PrettyMultipart(t T, data string) string {
+	boundary := multipartBoundary(data)
+	start := strings.Index(data, "--"+boundary)
+	if boundary == "" || start < 0 {
+		t.Errorf("failed to find multipart boundary")
+		return data
+	}
+
+	var parts []multipartPart
+	r := multipart.NewReader(strings.NewReader(data[start:]), boundary)
+	for {
+		p, err := r.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			NoError(t, err, "failed to parse multipart body")
+			return data
+		}
+
+		body, err := io.ReadAll(p)
+		if err != nil {
+			NoError(t, err, "failed to read multipart body")
+			return data
+		}
+		parts = append(parts, multipartPart{name: p.FormName(), fileName: p.FileName(), header: p.Header, body: string(body)})
+	}
+
+	sort.SliceStable(parts, func(i, j int) bool {
+		if parts[i].name != parts[j].name {
+			return parts[i].name < parts[j].name
+		}
+		return parts[i].fileName < parts[j].fileName
+	})
+
+	sb := &strings.Builder{}
+	sb.WriteString(strings.ReplaceAll(data[:start], boundary, MultipartBoundary))
+	for _, p := range parts {
+		sb.WriteString("--" + MultipartBoundary + "\n")
+		keys := make([]string, 0, len(p.header))
+		for k := range p.header {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			for _, v := range p.header[k] {
+				sb.WriteString(k + ": " + v + "\n")
+			}
+		}
+		sb.WriteString("\n" + p.body + "\n")
+	}
+	sb.WriteString("--" + MultipartBoundary + "--\n")
+	return sb.String()
+}
+
+type multipartPart struct {
+	name, fileName string
+	header         map[string][]string
+	body           string
+}
+
+// multipartBoundary returns the boundary from the Content-Type header or the first delimiter line of the data.
+func multipartBoundary(data string) string {
+	if m := boundaryParam.FindStringSubmatch(data); m != nil {
+		return m[1]
+	}
+
+	line, _, _ := strings.Cut(strings.TrimLeft(data, "\r\n"), "\n")
+	if b, ok := strings.CutPrefix(strings.TrimRight(line, "\r"), "--"); ok {
+		return b
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package golden_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrettyMultipart(t *testing.T) {
+	upload := func(fields ...string) (string, string) {
+		buf := &bytes.Buffer{}
+		w := multipart.NewWriter(buf)
+		for _, f := range fields {
+			if f == "file" {
+				fw, err := w.CreateFormFile("file", "report.csv")
+				require.NoError(t, err)
+				_, err = fw.Write([]byte("a,b\n1,2\n"))
+				require.NoError(t, err)
+				continue
+			}
+			require.NoError(t, w.WriteField(f, "value of "+f))
+		}
+		require.NoError(t, w.Close())
+		return buf.String(), w.FormDataContentType()
+	}
+
+	body1, _ := upload("title", "file", "author")
+	body2, contentType := upload("file", "author", "title")
+	assert.NotEqual(t, body1, body2)
+
+	expected := `--golden-boundary
+Content-Disposition: form-data; name="author"
+
+value of author
+--golden-boundary
+Content-Disposition: form-data; name="file"; filename="report.csv"
+Content-Type: application/octet-stream
+
+a,b
+1,2
+
+--golden-boundary
+Content-Disposition: form-data; name="title"
+
+value of title
+--golden-boundary--
+`
+	assert.Equal(t, expected, golden.PrettyMultipart(t, body1))
+	assert.Equal(t, expected, golden.PrettyMultipart(t, body2))
+
+	request := "POST /upload HTTP/1.1\r\nContent-Type: " + contentType + "\r\n\r\n" + body2
+	assert.Equal(t, "POST /upload HTTP/1.1\r\nContent-Type: multipart/form-data; boundary=golden-boundary\r\n\r\n"+expected,
+		golden.PrettyMultipart(t, request))
+
+	mt := &mockT{}
+	assert.Equal(t, "plain", golden.PrettyMultipart(mt, "plain"))
+	assert.True(t, mt.failed)
+}
@@ -0,0 +1,18 @@
+package golden
+
+// AssertFile checks the data against the golden file at the given path instead of the one derived from the test name,
+// so renaming the test doesn't orphan the fixture and multiple tests can intentionally assert against the same golden file:
+//
+//	golden.AssertFile(t, "testdata/fixtures/invoice_v2.json", got)
+//
+// Relative paths are relative to the package directory like testdata. The other options of the handler apply as with Assert.
+func AssertFile(t T, path, data string) bool {
+	return Default().AssertFile(t, path, data)
+}
+
+func (h *FileHandler) AssertFile(t T, path, data string) bool {
+	t.Helper()
+	c := *h
+	c.FileName = func(T) string { return path }
+	return c.Assert(t, data)
+}
@@ -0,0 +1,38 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}
+	path := "testdata/fixtures/invoice_v2.json"
+
+	mt := &mockT{name: "TestCreate"}
+	assert.True(t, fh.AssertFile(mt, path, `{"total": 42}`))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"total": 42}`, string(b))
+	assert.NoDirExists(t, "testdata/TestCreate")
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	for _, name := range []string{"TestInvoice", "TestRenamed/sub"} {
+		mt = &mockT{name: name}
+		assert.True(t, fh.AssertFile(mt, path, `{"total": 42}`))
+		assert.False(t, mt.failed)
+	}
+
+	mt = &mockT{name: "TestInvoice"}
+	assert.False(t, fh.AssertFile(mt, path, `{"total": 43}`))
+	assert.Contains(t, mt.msg, path)
+}
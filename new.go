@@ -0,0 +1,81 @@
+package golden
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// Option configures the handler created by New.
+type Option func(h *FileHandler)
+
+// CleanupT is T which registers cleanup functions, e.g. *testing.T.
+type CleanupT interface {
+	T
+	Cleanup(f func())
+}
+
+// New returns a copy of the default handler, see Default, configured with the options for the test and its subtests,
+// so the per-test configuration doesn't leak into other tests through the handler of the package-level functions:
+//
+//	h := golden.New(t, func(h *golden.FileHandler) { h.WriteDiff = true })
+//	h.Assert(t, render())
+//
+// The failure artifacts of the golden files which matched, left behind by the previous runs, are removed when
+// the test finishes: the .diff files of WriteDiff, the .pending files and the .actual.png and .diff.png images
+// of AssertImage. The pending files are kept when WritePending is set.
+func New(t CleanupT, opts ...Option) *FileHandler {
+	c := *Default()
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	matched := &matchedFiles{files: map[string]bool{}}
+	c.Recorders = append(c.Recorders[:len(c.Recorders):len(c.Recorders)], matched)
+	t.Cleanup(func() {
+		for _, fileName := range matched.list() {
+			for _, artifact := range failureArtifacts(fileName) {
+				if c.WritePending && strings.HasSuffix(artifact, PendingSuffix) {
+					continue
+				}
+				_ = os.Remove(artifact)
+			}
+		}
+	})
+	return &c
+}
+
+// failureArtifacts returns the names of the files written next to the golden file on mismatch.
+func failureArtifacts(fileName string) []string {
+	artifacts := []string{fileName + ".diff", fileName + PendingSuffix}
+	if base, ok := strings.CutSuffix(fileName, ".png"); ok {
+		artifacts = append(artifacts, base+".actual.png", base+".diff.png")
+	}
+	return artifacts
+}
+
+// matchedFiles records whether the golden files matched in all the assertions.
+type matchedFiles struct {
+	mu    sync.Mutex
+	files map[string]bool
+}
+
+func (m *matchedFiles) RecordResult(_ string, res Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if matched, ok := m.files[res.GoldenPath]; !ok || matched {
+		m.files[res.GoldenPath] = res.Matched
+	}
+}
+
+func (m *matchedFiles) list() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	files := make([]string, 0, len(m.files))
+	for f, matched := range m.files {
+		if matched {
+			files = append(files, f)
+		}
+	}
+	return files
+}
@@ -0,0 +1,54 @@
+package golden_test
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"matched", "pending"} {
+		for _, suffix := range []string{"", ".diff", golden.PendingSuffix} {
+			require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestNew/"+name+".golden"+suffix, []byte("data")))
+		}
+	}
+
+	t.Run("matched", func(t *testing.T) {
+		golden.New(t).Assert(t, "data")
+	})
+	assertFileContent(t, "testdata/TestNew/matched.golden", "data")
+	assert.NoFileExists(t, "testdata/TestNew/matched.golden.diff")
+	assert.NoFileExists(t, "testdata/TestNew/matched.golden"+golden.PendingSuffix)
+
+	t.Run("pending", func(t *testing.T) {
+		h := golden.New(t, func(h *golden.FileHandler) { h.WritePending = true })
+		assert.True(t, h.WritePending)
+		assert.False(t, golden.DefaultHandler.WritePending, "DefaultHandler isn't modified")
+		h.Assert(t, "data")
+	})
+	assert.NoFileExists(t, "testdata/TestNew/pending.golden.diff")
+	assert.FileExists(t, "testdata/TestNew/pending.golden"+golden.PendingSuffix, "pending files are kept in pending mode")
+}
+
+func TestNew_Image(t *testing.T) {
+	t.Chdir(t.TempDir())
+	img := testImage(color.NRGBA{R: 10, G: 20, B: 30, A: 255})
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, img))
+	for _, name := range []string{"TestImage.png", "TestImage.actual.png", "TestImage.diff.png"} {
+		require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestNew_Image/"+name, buf.Bytes()))
+	}
+
+	t.Run("TestImage", func(t *testing.T) {
+		golden.New(t).AssertImage(t, img)
+	})
+	assert.FileExists(t, "testdata/TestNew_Image/TestImage.png")
+	assert.NoFileExists(t, "testdata/TestNew_Image/TestImage.actual.png")
+	assert.NoFileExists(t, "testdata/TestNew_Image/TestImage.diff.png")
+}
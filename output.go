@@ -0,0 +1,78 @@
+package golden
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+)
+
+// Output captures the data written to os.Stdout and os.Stderr while fn runs and checks it against the golden file,
+// e.g. for printers and CLI commands writing directly to the standard streams. The golden file contains both streams:
+//
+//	--- stdout
+//	hello
+//	--- stderr
+//	warning: something
+//
+// The standard streams are process wide, so Output must not be used in parallel tests.
+func Output(t T, fn func()) bool {
+	return Default().Output(t, fn)
+}
+
+func (h *FileHandler) Output(t T, fn func()) bool {
+	t.Helper()
+	stdout, stderr, err := CaptureOutput(fn)
+	NoError(t, err, "failed to capture output")
+	return h.Assert(t, "--- stdout\n"+withNewline(stdout)+"--- stderr\n"+withNewline(stderr))
+}
+
+// CaptureOutput runs fn with os.Stdout and os.Stderr redirected to pipes and returns the data written to them.
+// The streams are restored also when fn panics.
+func CaptureOutput(fn func()) (stdout, stderr string, err error) {
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return "", "", err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		return "", "", err
+	}
+
+	var wg sync.WaitGroup
+	var outBuf, errBuf bytes.Buffer
+	for _, p := range []struct {
+		r   *os.File
+		buf *bytes.Buffer
+	}{{outR, &outBuf}, {errR, &errBuf}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = io.Copy(p.buf, p.r)
+			p.r.Close()
+		}()
+	}
+
+	origOut, origErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+	defer func() {
+		os.Stdout, os.Stderr = origOut, origErr
+		outW.Close()
+		errW.Close()
+		wg.Wait()
+		stdout, stderr = outBuf.String(), errBuf.String()
+	}()
+
+	fn()
+	return "", "", nil
+}
+
+// withNewline terminates non-empty data with a newline, so the sections of the transcripts start on their own lines.
+func withNewline(data string) string {
+	if data != "" && data[len(data)-1] != '\n' {
+		return data + "\n"
+	}
+	return data
+}
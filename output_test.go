@@ -0,0 +1,31 @@
+package golden_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	golden.Output(t, func() {
+		fmt.Println("hello")
+		fmt.Fprint(os.Stderr, "warning: something")
+	})
+}
+
+func TestCaptureOutput(t *testing.T) {
+	stdout, stderr, err := golden.CaptureOutput(func() { fmt.Print("out") })
+	require.NoError(t, err)
+	assert.Equal(t, "out", stdout)
+	assert.Empty(t, stderr)
+
+	orig := os.Stdout
+	assert.Panics(t, func() {
+		_, _, _ = golden.CaptureOutput(func() { panic("boom") })
+	})
+	assert.Same(t, orig, os.Stdout, "stdout is restored after panic")
+}
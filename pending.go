@@ -0,0 +1,81 @@
+package golden
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PendingSuffix is appended to the golden file name for the pending content written with WritePending.
+const PendingSuffix = ".pending"
+
+// writePending writes the content into the pending file when it differs from the golden file,
+// otherwise the stale pending file is removed. The pending files are written only to the OS filesystem,
+// where PendingFiles and Approve find them, false is returned after failing the test for the other storages.
+func (h *FileHandler) writePending(t T, fileName, content string) bool {
+	t.Helper()
+	if _, ok := h.storage().(OSStorage); !ok {
+		NoError(t, errors.New("Approve promotes the pending files only in the OS filesystem, Storage must be OSStorage"), "failed to write pending golden file")
+		return false
+	}
+
+	pending := fileName + PendingSuffix
+	if old, err := h.readFile(fileName, false); err == nil && string(old) == content {
+		if err := removeStale(h.storage(), pending); err != nil {
+			t.Logf("failed to remove stale pending golden file: %s", err)
+		}
+		return true
+	}
+
+	t.Logf("pending golden file written: %s\napprove with: go run github.com/go-tstr/golden/cmd/golden approve", pending)
+	NoError(t, h.storage().WriteFile(pending, []byte(content)), "failed to write pending golden file")
+	return true
+}
+
+// PendingFiles returns the pending golden files under the root directory, see WritePending.
+// Hidden directories and vendor directories are skipped.
+func PendingFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, PendingSuffix) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) && len(files) == 0 {
+		return nil, nil
+	}
+	sort.Strings(files)
+	return files, err
+}
+
+// Approve promotes the pending golden files under the root directory by replacing the golden files with them
+// and returns the paths of the updated golden files.
+func Approve(root string) ([]string, error) {
+	pending, err := PendingFiles(root)
+	if err != nil {
+		return nil, err
+	}
+
+	approved := make([]string, 0, len(pending))
+	for _, p := range pending {
+		fileName := strings.TrimSuffix(p, PendingSuffix)
+		if err := os.Rename(p, fileName); err != nil {
+			return approved, err
+		}
+		approved = append(approved, fileName)
+	}
+	return approved, nil
+}
@@ -0,0 +1,66 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePending(t *testing.T) {
+	t.Chdir(t.TempDir())
+	name := "testdata/TestPending/TestPending.golden"
+	require.NoError(t, golden.OSStorage{}.WriteFile(name, []byte("old")))
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		WritePending:   true,
+	}
+
+	mt := &mockT{name: "TestPending"}
+	assert.True(t, fh.Assert(mt, "new"))
+	assert.Contains(t, mt.logs, "pending golden file written: "+name+".pending")
+	assertFileContent(t, name, "old")
+	assertFileContent(t, name+golden.PendingSuffix, "new")
+
+	pending, err := golden.PendingFiles("testdata")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"testdata/TestPending/TestPending.golden.pending"}, pending)
+
+	assert.True(t, fh.Assert(&mockT{name: "TestPending"}, "old"))
+	assert.NoFileExists(t, name+golden.PendingSuffix, "stale pending file is removed")
+
+	assert.True(t, fh.Assert(&mockT{name: "TestPending/new"}, "created"))
+	approved, err := golden.Approve(".")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"testdata/TestPending/new.golden"}, approved)
+	assertFileContent(t, "testdata/TestPending/new.golden", "created")
+
+	pending, err = golden.PendingFiles("missing")
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func assertFileContent(t *testing.T, name, want string) {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(b), name)
+}
+
+func TestWritePending_Storage(t *testing.T) {
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		WritePending:   true,
+		Storage:        &memStorage{files: map[string]string{}},
+	}
+
+	mt := &mockT{name: "TestPending"}
+	assert.False(t, fh.Assert(mt, "new"))
+	assert.Contains(t, mt.msg, "failed to write pending golden file: Approve promotes the pending files only in the OS filesystem")
+}
@@ -0,0 +1,64 @@
+package golden
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Protected returns a copy of the handler which fails the assertion instead of recreating the golden files
+// matching any of the globs, e.g. contract or compatibility fixtures which should change only through
+// a separate, explicit process. The globs use path.Match syntax and are matched against the slash separated
+// golden file path and its parent directories, so "testdata/contracts" protects all the files below it.
+// Relative globs are matched against the path relative to the working directory and relative to the module root,
+// so they also protect the absolute paths, e.g. of ModuleRoot or SharedFixture:
+//
+//	var goldenHandler = golden.DefaultHandler.Protected("testdata/contracts", "testdata/*/v1.golden")
+func (h *FileHandler) Protected(globs ...string) *FileHandler {
+	c := *h
+	c.ProtectedFiles = append(append([]string(nil), h.ProtectedFiles...), globs...)
+	return &c
+}
+
+// protected reports whether the golden file matches any of the ProtectedFiles globs.
+func (h *FileHandler) protected(fileName string) (bool, error) {
+	names := protectedNames(fileName)
+	for _, glob := range h.ProtectedFiles {
+		glob = path.Clean(filepath.ToSlash(glob))
+		for _, name := range names {
+			for i := len(name); i > 0; i = strings.LastIndex(name[:i], "/") {
+				ok, err := path.Match(glob, name[:i])
+				if err != nil || ok {
+					return ok, err
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// protectedNames returns the slash separated golden file path as given, absolute, and relative to the working
+// directory and to the module root.
+func protectedNames(fileName string) []string {
+	names := []string{path.Clean(filepath.ToSlash(fileName))}
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return names
+	}
+	names = append(names, filepath.ToSlash(abs))
+
+	var bases []string
+	if wd, err := os.Getwd(); err == nil {
+		bases = append(bases, wd)
+	}
+	if root, err := FindModuleRoot(); err == nil {
+		bases = append(bases, root)
+	}
+	for _, base := range bases {
+		if rel, err := filepath.Rel(base, abs); err == nil {
+			names = append(names, filepath.ToSlash(rel))
+		}
+	}
+	return names
+}
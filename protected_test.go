@@ -0,0 +1,66 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtected(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}).Protected("testdata/TestContract", "./testdata/*/v1.golden")
+
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestContract/TestContract.golden", []byte("keep")))
+	for _, name := range []string{"TestContract", "TestContract/nested", "TestAPI/v1"} {
+		mt := &mockT{name: name}
+		assert.False(t, fh.Assert(mt, "changed"), name)
+		assert.True(t, mt.failed, name)
+		assert.Contains(t, mt.msg, "is protected and can't be recreated", name)
+	}
+	b, err := os.ReadFile("testdata/TestContract/TestContract.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "keep", string(b))
+	assert.NoFileExists(t, "testdata/TestAPI/v1.golden")
+
+	mt := &mockT{name: "TestAPI/v2"}
+	assert.True(t, fh.Assert(mt, "data"))
+	assert.False(t, mt.failed)
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt = &mockT{name: "TestContract"}
+	assert.True(t, fh.Assert(mt, "keep"), "protected files are compared as usual")
+
+	mt = &mockT{name: "TestOther"}
+	fh = fh.Protected("[")
+	fh.ShouldRecreate = func(golden.T) bool { return true }
+	fh.Assert(mt, "data")
+	assert.Contains(t, mt.msg, "invalid protected golden file pattern")
+}
+
+func TestProtected_ModuleRoot(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/m\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "pkg"), 0o755))
+	t.Chdir(filepath.Join(root, "pkg"))
+
+	for _, glob := range []string{"testdata/TestContract", "../testdata/TestContract/*.golden"} {
+		fh := (&golden.FileHandler{
+			FileName:       golden.ModuleRoot("testdata"),
+			ShouldRecreate: func(golden.T) bool { return true },
+			Equal:          golden.EqualWithDiff,
+		}).Protected(glob)
+
+		mt := &mockT{name: "TestContract"}
+		assert.False(t, fh.Assert(mt, "changed"), glob)
+		assert.Contains(t, mt.msg, "is protected and can't be recreated", glob)
+		assert.NoFileExists(t, filepath.Join(root, "testdata/TestContract/TestContract.golden"), glob)
+	}
+}
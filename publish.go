@@ -0,0 +1,29 @@
+package golden
+
+// Mismatch describes a failed golden file assertion.
+type Mismatch struct {
+	// Test is the name of the test.
+	Test string
+	// GoldenFile is the path of the golden file.
+	GoldenFile string
+	// Expected is the golden file content.
+	Expected string
+	// Actual is the processed actual content.
+	Actual string
+	// DiffFile is the path of the diff artifact written for the mismatch, empty when none was written.
+	DiffFile string
+}
+
+// Publisher publishes the mismatches to an external review service, e.g. a visual review platform,
+// and returns the URL where the mismatch can be reviewed.
+type Publisher interface {
+	Publish(m Mismatch) (url string, err error)
+}
+
+// PublisherFunc is an adapter which allows using ordinary functions as Publisher.
+type PublisherFunc func(m Mismatch) (string, error)
+
+// Publish calls f(m).
+func (f PublisherFunc) Publish(m Mismatch) (string, error) {
+	return f(m)
+}
@@ -0,0 +1,46 @@
+package golden_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestPublisher"), "failed to remove testdata") })
+	require.NoError(t, os.MkdirAll("./testdata/TestPublisher", 0o755))
+	require.NoError(t, os.WriteFile("./testdata/TestPublisher/TestPublisher.golden", []byte("expected"), 0o600))
+
+	var published []golden.Mismatch
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		Publisher: golden.PublisherFunc(func(m golden.Mismatch) (string, error) {
+			published = append(published, m)
+			return "https://review.example.com/1", nil
+		}),
+	}
+
+	mt := &mockT{name: "TestPublisher"}
+	assert.True(t, fh.Assert(mt, "expected"))
+	assert.Empty(t, published)
+
+	assert.False(t, fh.Assert(mt, "actual"))
+	assert.Equal(t, []golden.Mismatch{{
+		Test:       "TestPublisher",
+		GoldenFile: "testdata/TestPublisher/TestPublisher.golden",
+		Expected:   "expected",
+		Actual:     "actual",
+	}}, published)
+	assert.Contains(t, mt.msg, "review the mismatch at https://review.example.com/1")
+
+	fh.Publisher = golden.PublisherFunc(func(golden.Mismatch) (string, error) { return "", errors.New("unavailable") })
+	mt = &mockT{name: "TestPublisher"}
+	assert.False(t, fh.Assert(mt, "actual"))
+	assert.Contains(t, mt.logs, "failed to publish the mismatch: unavailable")
+}
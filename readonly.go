@@ -0,0 +1,32 @@
+package golden
+
+// ReadOnly returns a copy of the handler which never recreates the golden files, it's intended for CI jobs
+// verifying that the committed golden files are up to date.
+// The assertion fails when the golden file is missing, with the exact commands for creating it,
+// and when recreating is requested, e.g. with GOLDEN_FILES_RECREATE, since golden files written in CI would be silently lost.
+func (h *FileHandler) ReadOnly() *FileHandler {
+	c := *h
+	c.MissingFile = MissingFileFail
+	c.FailOnRecreate = true
+	return &c
+}
+
+// readOnly fails the assertion of the read-only handler when the golden file would be written,
+// it reports whether the assertion can continue.
+func (h *FileHandler) readOnly(t T, fileName string, recreate bool) bool {
+	t.Helper()
+	if recreate {
+		t.Errorf("golden file %s would be recreated but recreating is disabled in read-only mode, "+
+			"run the tests locally with GOLDEN_FILES_RECREATE=true and commit the changes", fileName)
+		t.FailNow()
+		return false
+	}
+
+	if !h.exists(fileName) {
+		t.Errorf("golden file %s doesn't exist and recreating is disabled in read-only mode, create it with:\n"+
+			"\tGOLDEN_FILES_RECREATE=true go test -run '%s' .\n\tgit add %s", fileName, runPattern(t), fileName)
+		t.FailNow()
+		return false
+	}
+	return true
+}
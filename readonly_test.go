@@ -0,0 +1,70 @@
+package golden_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnly(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: golden.ParseRecreateFromEnv,
+		Equal:          golden.EqualWithDiff,
+		MissingFile:    golden.MissingFileCreate,
+	}).ReadOnly()
+
+	mt := &mockT{name: "TestReadOnly/missing"}
+	assert.False(t, fh.Assert(mt, "data"))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "golden file testdata/TestReadOnly/missing.golden doesn't exist and recreating is disabled in read-only mode, create it with:\n"+
+		"\tGOLDEN_FILES_RECREATE=true go test -run '^TestReadOnly$/^missing$' .\n\tgit add testdata/TestReadOnly/missing.golden")
+	assert.NoFileExists(t, "testdata/TestReadOnly/missing.golden")
+
+	require.NoError(t, os.MkdirAll("testdata/TestReadOnly", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestReadOnly/existing.golden", []byte("data"), 0o600))
+	mt = &mockT{name: "TestReadOnly/existing"}
+	assert.True(t, fh.Assert(mt, "data"))
+	assert.False(t, mt.failed)
+
+	t.Setenv("GOLDEN_FILES_RECREATE", "true")
+	mt = &mockT{name: "TestReadOnly/existing"}
+	fh.Assert(mt, "other")
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "golden file testdata/TestReadOnly/existing.golden would be recreated but recreating is disabled in read-only mode")
+	b, err := os.ReadFile("testdata/TestReadOnly/existing.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(b))
+}
+
+func TestReadOnly_AssertImage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	img := image.NewGray(image.Rect(0, 0, 2, 2))
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, img))
+	require.NoError(t, os.MkdirAll("testdata/TestReadOnly", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestReadOnly/TestReadOnly.png", buf.Bytes(), 0o600))
+
+	recreate := false
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return recreate },
+		Equal:          golden.EqualWithDiff,
+	}).ReadOnly()
+
+	mt := &mockT{name: "TestReadOnly"}
+	assert.True(t, fh.AssertImage(mt, img), "the golden image is checked, not the .golden file")
+	assert.False(t, mt.failed, mt.msg)
+
+	recreate = true
+	mt = &mockT{name: "TestReadOnly"}
+	assert.False(t, fh.AssertImage(mt, img), "the original ShouldRecreate is respected")
+	assert.Contains(t, mt.msg, "golden file testdata/TestReadOnly/TestReadOnly.png would be recreated")
+}
@@ -0,0 +1,36 @@
+package golden
+
+import "regexp"
+
+// Redacted replaces the secrets removed by RedactSecrets.
+const Redacted = "<REDACTED>"
+
+// secretPatterns match the secrets redacted by RedactSecrets, the first group is kept in front of the placeholder
+// and the second one after it, so the surrounding syntax stays intact.
+var secretPatterns = []*regexp.Regexp{
+	// Header values of the credentials.
+	regexp.MustCompile(`(?im)^((?:authorization|proxy-authorization|cookie|x-api-key|x-auth-token)\s*:[ \t]*)\S.*?(\r?)$`),
+	// Bearer tokens, e.g. in logged requests.
+	regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9\-._~+/]+=*()`),
+	// JSON values of the credential fields.
+	regexp.MustCompile(`(?i)("(?:password|passwd|pwd|secret|client_secret|token|access_token|refresh_token|id_token|api_?key|private_key|aws_secret_access_key)"\s*:\s*")(?:[^"\\]|\\.)*(")`),
+	// AWS secret access keys in configuration files.
+	regexp.MustCompile(`(?i)(aws_secret_access_key\s*[=:]\s*"?)[A-Za-z0-9/+=]{40}()`),
+	// AWS access key IDs.
+	regexp.MustCompile(`()\b(?:AKIA|ASIA)[0-9A-Z]{16}\b()`),
+	// JSON Web Tokens.
+	regexp.MustCompile(`()\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*()`),
+}
+
+// RedactSecrets returns ProcessContent function which replaces credentials with <REDACTED>, so that golden files
+// captured from real services never leak them. Built-in patterns cover credential headers, bearer tokens,
+// JWTs, AWS keys and JSON fields like "password", "secret", "token" and "api_key".
+// Use Scrubbers for redacting the application specific values.
+func RedactSecrets() func(T, string) string {
+	return func(_ T, data string) string {
+		for _, p := range secretPatterns {
+			data = p.ReplaceAllString(data, "${1}"+Redacted+"${2}")
+		}
+		return data
+	}
+}
@@ -0,0 +1,233 @@
+package golden
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// RemoteStorage stores the golden files on an HTTP server, e.g. an S3 bucket or any object store supporting GET and PUT,
+// so very large fixtures can be hosted outside the repository. The URL of the golden file is the slash separated
+// file name joined to BaseURL, e.g. https://fixtures.example.com/golden/testdata/TestX/TestX.golden.
+//
+// The downloaded files are cached in CacheDir together with their ETag and SHA-256 hash. The cached file is
+// revalidated with If-None-Match on each read, so unchanged fixtures aren't downloaded again, and the cached content
+// is verified against the hash, so a corrupted cache is downloaded again. The cached file is used when the server
+// can't be reached, which keeps the tests working offline once the fixtures were downloaded:
+//
+//	golden.DefaultHandler.Storage = golden.RemoteStorage{
+//		BaseURL: "https://fixtures.example.com/golden",
+//		Header:  http.Header{"Authorization": {"Bearer " + os.Getenv("FIXTURES_TOKEN")}},
+//	}
+type RemoteStorage struct {
+	// BaseURL is the URL of the directory of the golden files.
+	BaseURL string
+	// Client sends the requests, http.DefaultClient is used when nil.
+	Client Client
+	// Header is added to all the requests, e.g. for authorization.
+	Header http.Header
+	// CacheDir is the directory of the downloaded files, golden in os.UserCacheDir is used when empty.
+	CacheDir string
+}
+
+// remoteCacheEntry is the metadata of the cached file.
+type remoteCacheEntry struct {
+	ETag   string `json:"etag"`
+	SHA256 string `json:"sha256"`
+}
+
+// ReadFile downloads the file unless the cached one is up to date, 404 Not Found is reported as fs.ErrNotExist.
+func (s RemoteStorage) ReadFile(name string) ([]byte, error) {
+	cacheFile, err := s.cacheFile(name)
+	if err != nil {
+		return nil, err
+	}
+	cached, entry, cacheErr := readRemoteCache(cacheFile)
+
+	req, err := s.request(http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cacheErr == nil && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	resp, err := s.client().Do(req)
+	if err != nil {
+		if cacheErr == nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cacheErr == nil:
+		return cached, nil
+	case resp.StatusCode == http.StatusNotFound:
+		_ = os.Remove(cacheFile)
+		_ = os.Remove(cacheFile + ".json")
+		return nil, fmt.Errorf("%s: %w", req.URL, fs.ErrNotExist)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to download %s: %s", req.URL, resp.Status)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", req.URL, err)
+	}
+	if err := writeRemoteCache(cacheFile, b, resp.Header.Get("ETag")); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// WriteFile uploads the file with PUT and caches it with the ETag of the response.
+func (s RemoteStorage) WriteFile(name string, data []byte) error {
+	req, err := s.request(http.MethodPut, name, data)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to upload %s: %s", req.URL, resp.Status)
+	}
+
+	cacheFile, err := s.cacheFile(name)
+	if err != nil {
+		return err
+	}
+	return writeRemoteCache(cacheFile, data, resp.Header.Get("ETag"))
+}
+
+// Stat checks the file with HEAD request without downloading it, 404 Not Found is reported as fs.ErrNotExist.
+// The cached file is used when the server can't be reached.
+func (s RemoteStorage) Stat(name string) (fs.FileInfo, error) {
+	req, err := s.request(http.MethodHead, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client().Do(req)
+	if err != nil {
+		cacheFile, cacheErr := s.cacheFile(name)
+		if cacheErr != nil {
+			return nil, err
+		}
+		cached, _, cacheErr := readRemoteCache(cacheFile)
+		if cacheErr != nil {
+			return nil, err
+		}
+		return storageFileInfo{name: path.Base(filepath.ToSlash(name)), size: int64(len(cached))}, nil
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", req.URL, fs.ErrNotExist)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to check %s: %s", req.URL, resp.Status)
+	}
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return storageFileInfo{name: path.Base(filepath.ToSlash(name)), size: resp.ContentLength, modTime: modTime}, nil
+}
+
+func (s RemoteStorage) request(method, name string, body []byte) (*http.Request, error) {
+	url := strings.TrimSuffix(s.BaseURL, "/") + "/" + strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "/")
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range s.Header {
+		req.Header[k] = v
+	}
+	return req, nil
+}
+
+func (s RemoteStorage) client() Client {
+	if s.Client == nil {
+		return http.DefaultClient
+	}
+	return s.Client
+}
+
+// cacheFile returns the path of the cached file, named by the hash of its URL.
+func (s RemoteStorage) cacheFile(name string) (string, error) {
+	dir := s.CacheDir
+	if dir == "" {
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(userDir, "golden")
+	}
+	req, err := s.request(http.MethodGet, name, nil)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(req.URL.String()))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])), nil
+}
+
+// readRemoteCache returns the cached content when it matches the hash of its metadata.
+func readRemoteCache(cacheFile string) ([]byte, remoteCacheEntry, error) {
+	var entry remoteCacheEntry
+	meta, err := os.ReadFile(cacheFile + ".json")
+	if err != nil {
+		return nil, entry, err
+	}
+	if err := json.Unmarshal(meta, &entry); err != nil {
+		return nil, entry, err
+	}
+	b, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return nil, entry, err
+	}
+	if sum := sha256.Sum256(b); hex.EncodeToString(sum[:]) != entry.SHA256 {
+		return nil, entry, errors.New("cached file " + cacheFile + " is corrupted")
+	}
+	return b, entry, nil
+}
+
+// writeRemoteCache writes the content and its metadata, the files are renamed into place, so concurrent readers
+// don't see partially written files.
+func writeRemoteCache(cacheFile string, data []byte, etag string) error {
+	sum := sha256.Sum256(data)
+	meta, err := json.Marshal(remoteCacheEntry{ETag: etag, SHA256: hex.EncodeToString(sum[:])})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0o755); err != nil {
+		return err
+	}
+	for name, b := range map[string][]byte{cacheFile: data, cacheFile + ".json": meta} {
+		f, err := os.CreateTemp(filepath.Dir(cacheFile), filepath.Base(name)+".*.tmp")
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(b)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(f.Name(), name)
+		}
+		if err != nil {
+			_ = os.Remove(f.Name())
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package golden_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureServer is a minimal object store serving the files with ETags.
+type fixtureServer struct {
+	mu        sync.Mutex
+	files     map[string]string
+	downloads int
+}
+
+func (s *fixtureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		b, _ := io.ReadAll(r.Body)
+		s.files[r.URL.Path] = string(b)
+		w.Header().Set("ETag", etag(string(b)))
+	case http.MethodHead:
+		data, ok := s.files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	case http.MethodGet:
+		data, ok := s.files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", etag(data))
+		if r.Header.Get("If-None-Match") == etag(data) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		s.downloads++
+		_, _ = io.WriteString(w, data)
+	}
+}
+
+func (s *fixtureServer) file(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.files[name]
+}
+
+func (s *fixtureServer) downloadCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.downloads
+}
+
+func etag(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+func TestRemoteStorage(t *testing.T) {
+	srv := &fixtureServer{files: map[string]string{"/golden/testdata/TestRemote/TestRemote.golden": "remote"}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	cacheDir := t.TempDir()
+	s := golden.RemoteStorage{
+		BaseURL:  ts.URL + "/golden/",
+		Header:   http.Header{"Authorization": {"Bearer token"}},
+		CacheDir: cacheDir,
+	}
+
+	name := "testdata/TestRemote/TestRemote.golden"
+	for range 2 {
+		b, err := s.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, "remote", string(b))
+	}
+	assert.Equal(t, 1, srv.downloadCount(), "unchanged file is revalidated with ETag")
+
+	srv.mu.Lock()
+	srv.files["/golden/"+name] = "changed"
+	srv.mu.Unlock()
+	b, err := s.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(b))
+	assert.Equal(t, 2, srv.downloadCount())
+
+	meta, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, meta, 1)
+	require.NoError(t, os.WriteFile(strings.TrimSuffix(meta[0], ".json"), []byte("corrupted"), 0o600))
+	b, err = s.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(b))
+	assert.Equal(t, 3, srv.downloadCount(), "corrupted cache is downloaded again")
+
+	require.NoError(t, s.WriteFile(name, []byte("uploaded")))
+	assert.Equal(t, "uploaded", srv.file("/golden/"+name))
+	b, err = s.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "uploaded", string(b))
+	assert.Equal(t, 3, srv.downloadCount(), "uploaded file is cached")
+
+	ts.Close()
+	b, err = s.ReadFile(name)
+	require.NoError(t, err, "cached file is used offline")
+	assert.Equal(t, "uploaded", string(b))
+}
+
+func TestRemoteStorageErrors(t *testing.T) {
+	srv := &fixtureServer{files: map[string]string{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	s := golden.RemoteStorage{BaseURL: ts.URL, Header: http.Header{"Authorization": {"Bearer token"}}, CacheDir: t.TempDir()}
+	_, err := s.ReadFile("testdata/missing.golden")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	s.Header = nil
+	_, err = s.ReadFile("testdata/missing.golden")
+	assert.ErrorContains(t, err, "403 Forbidden")
+	assert.ErrorContains(t, s.WriteFile("testdata/missing.golden", []byte("data")), "failed to upload")
+}
+
+func TestRemoteStorageStat(t *testing.T) {
+	srv := &fixtureServer{files: map[string]string{"/testdata/TestRemote/TestRemote.golden": "remote"}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	s := golden.RemoteStorage{BaseURL: ts.URL, Header: http.Header{"Authorization": {"Bearer token"}}, CacheDir: t.TempDir()}
+	info, err := s.Stat("testdata/TestRemote/TestRemote.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "TestRemote.golden", info.Name())
+	assert.Equal(t, int64(6), info.Size())
+	assert.Zero(t, srv.downloadCount(), "the file isn't downloaded")
+
+	_, err = s.Stat("testdata/missing.golden")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestRemoteStorageAssert(t *testing.T) {
+	t.Chdir(t.TempDir())
+	srv := &fixtureServer{files: map[string]string{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Storage:        golden.RemoteStorage{BaseURL: ts.URL, Header: http.Header{"Authorization": {"Bearer token"}}, CacheDir: t.TempDir()},
+	}
+	mt := &mockT{name: "TestRemote"}
+	assert.True(t, fh.Assert(mt, "data"))
+	assert.Equal(t, "data", srv.file("/testdata/TestRemote/TestRemote.golden"))
+	assert.NoFileExists(t, "testdata/TestRemote/TestRemote.golden")
+}
@@ -0,0 +1,214 @@
+package golden
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Move describes a golden file move from one path to another.
+type Move struct {
+	From string
+	To   string
+}
+
+// PlanRename returns the golden file moves needed after renaming tests, renames maps old test names to new ones.
+// Renaming a test renames also all of its subtests. Golden files are expected to follow the TestNameToFilePath layout under dir.
+//
+// When manifest (see Tracker) is given, the affected tests and their golden files are resolved from it.
+// Otherwise the golden files are discovered from dir: renaming a top level test moves its whole directory
+// and renaming a subtest moves the golden files with the subtest's file name as prefix, i.e. its nested subtests.
+// Sibling subtests sharing the prefix, e.g. "sub" and "sub a", can't be told apart without the manifest.
+func PlanRename(dir string, renames map[string]string, manifest map[string]string) ([]Move, error) {
+	for oldName, newName := range renames {
+		for _, name := range []string{oldName, newName} {
+			if _, err := localTestPath(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var moves []Move
+	if manifest != nil {
+		for name, from := range manifest {
+			newName, ok := renameTest(name, renames)
+			if !ok {
+				continue
+			}
+			moves = append(moves, Move{From: filepath.FromSlash(from), To: filepath.Join(dir, testNameToPath(newName))})
+		}
+	} else {
+		for oldName, newName := range renames {
+			m, err := planRenameFromDir(dir, oldName, newName)
+			if err != nil {
+				return nil, err
+			}
+			moves = append(moves, m...)
+		}
+	}
+
+	sort.Slice(moves, func(i, j int) bool { return moves[i].From < moves[j].From })
+	return moves, nil
+}
+
+func planRenameFromDir(dir, oldName, newName string) ([]Move, error) {
+	oldPath := filepath.Join(dir, testNameToPath(oldName))
+	newPath := filepath.Join(dir, testNameToPath(newName))
+	oldStem := strings.TrimSuffix(filepath.Base(oldPath), ".golden")
+	newStem := strings.TrimSuffix(filepath.Base(newPath), ".golden")
+	subtest := strings.Contains(oldName, "/")
+
+	entries, err := os.ReadDir(filepath.Dir(oldPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var moves []Move
+	for _, e := range entries {
+		to, ok := renameGoldenFile(e.Name(), oldStem, newStem)
+		if !ok && subtest {
+			continue
+		}
+		moves = append(moves, Move{From: filepath.Join(filepath.Dir(oldPath), e.Name()), To: filepath.Join(filepath.Dir(newPath), to)})
+	}
+	if subtest && len(moves) == 0 {
+		return nil, fmt.Errorf("no golden files found for test %q: %w", oldName, os.ErrNotExist)
+	}
+	return moves, nil
+}
+
+// renameGoldenFile renames the golden file of the test, its variants and the golden files of its subtests,
+// which are flattened to the same directory with an underscore separator by TestNameToFilePath.
+func renameGoldenFile(name, oldStem, newStem string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, oldStem)
+	if !ok || (!strings.HasPrefix(rest, ".") && !strings.HasPrefix(rest, "_")) {
+		return name, false
+	}
+	return newStem + rest, true
+}
+
+// RenameManifest returns a copy of the manifest with the renamed tests pointing to their moved golden files.
+func RenameManifest(dir string, manifest, renames map[string]string) map[string]string {
+	renamed := make(map[string]string, len(manifest))
+	for name, fileName := range manifest {
+		if newName, ok := renameTest(name, renames); ok {
+			name, fileName = newName, filepath.ToSlash(filepath.Join(dir, testNameToPath(newName)))
+		}
+		renamed[name] = fileName
+	}
+	return renamed
+}
+
+// renameTest returns the new name for the test if it or any of its parents is renamed.
+// The longest matching old name wins, so renaming both TestA and TestA/sub renames TestA/sub/x by the latter.
+func renameTest(name string, renames map[string]string) (string, bool) {
+	oldNames := make([]string, 0, len(renames))
+	for oldName := range renames {
+		oldNames = append(oldNames, oldName)
+	}
+	sort.Slice(oldNames, func(i, j int) bool {
+		if len(oldNames[i]) != len(oldNames[j]) {
+			return len(oldNames[i]) > len(oldNames[j])
+		}
+		return oldNames[i] < oldNames[j]
+	})
+
+	for _, oldName := range oldNames {
+		newName := renames[oldName]
+		if name == oldName {
+			return newName, true
+		}
+		if rest, ok := strings.CutPrefix(name, oldName+"/"); ok {
+			return newName + "/" + rest, true
+		}
+	}
+	return "", false
+}
+
+// DiffManifests detects renamed tests by comparing manifests of two tracked runs, it returns old to new test name mappings.
+// A test missing from the current manifest is paired with a new test when there is exactly one candidate which either
+// has the same subtest name under a renamed top level test or is the only new subtest under the same parent test.
+func DiffManifests(previous, current map[string]string) map[string]string {
+	var removed, added []string
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	for name := range current {
+		if _, ok := previous[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+
+	renames := map[string]string{}
+	used := map[string]bool{}
+	for _, r := range removed {
+		var candidates []string
+		for _, a := range added {
+			if !used[a] && isRenameCandidate(r, a, removed, added) {
+				candidates = append(candidates, a)
+			}
+		}
+		if len(candidates) == 1 {
+			renames[r] = candidates[0]
+			used[candidates[0]] = true
+		}
+	}
+	return renames
+}
+
+func isRenameCandidate(oldName, newName string, removed, added []string) bool {
+	oldTop, oldSub, _ := strings.Cut(oldName, "/")
+	newTop, newSub, _ := strings.Cut(newName, "/")
+	if oldTop != newTop {
+		return oldSub == newSub
+	}
+
+	oldParent, newParent := filepath.Dir(oldName), filepath.Dir(newName)
+	return oldParent == newParent && countWithParent(removed, oldParent) == 1 && countWithParent(added, newParent) == 1
+}
+
+func countWithParent(names []string, parent string) int {
+	n := 0
+	for _, name := range names {
+		if filepath.Dir(name) == parent {
+			n++
+		}
+	}
+	return n
+}
+
+// ApplyMoves moves the golden files, files tracked by git are moved with git mv to preserve their history.
+// The target directories are created when needed and existing target files are never overwritten.
+func ApplyMoves(moves []Move) error {
+	for _, m := range moves {
+		if _, err := os.Stat(m.To); err == nil {
+			return fmt.Errorf("target golden file %q already exists", m.To)
+		}
+		if err := os.MkdirAll(filepath.Dir(m.To), 0o755); err != nil {
+			return err
+		}
+
+		if isGitTracked(m.From) {
+			if out, err := exec.Command("git", "mv", m.From, m.To).CombinedOutput(); err != nil {
+				return fmt.Errorf("git mv %s %s: %w: %s", m.From, m.To, err, out)
+			}
+			continue
+		}
+
+		if err := os.Rename(m.From, m.To); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isGitTracked(path string) bool {
+	return exec.Command("git", "ls-files", "--error-unmatch", path).Run() == nil
+}
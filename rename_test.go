@@ -0,0 +1,120 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRename_FromDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "TestOld/TestOld.golden", "TestOld/sub.golden", "TestOther/sub_a.golden")
+
+	moves, err := golden.PlanRename(dir, map[string]string{"TestOld": "TestNew", "TestOther/sub a": "TestOther/sub b"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []golden.Move{
+		{From: filepath.Join(dir, "TestOld/TestOld.golden"), To: filepath.Join(dir, "TestNew/TestNew.golden")},
+		{From: filepath.Join(dir, "TestOld/sub.golden"), To: filepath.Join(dir, "TestNew/sub.golden")},
+		{From: filepath.Join(dir, "TestOther/sub_a.golden"), To: filepath.Join(dir, "TestOther/sub_b.golden")},
+	}, moves)
+
+	require.NoError(t, golden.ApplyMoves(moves))
+	assert.FileExists(t, filepath.Join(dir, "TestNew/TestNew.golden"))
+	assert.FileExists(t, filepath.Join(dir, "TestNew/sub.golden"))
+	assert.FileExists(t, filepath.Join(dir, "TestOther/sub_b.golden"))
+	assert.NoFileExists(t, filepath.Join(dir, "TestOld/sub.golden"))
+}
+
+func TestPlanRename_FromDirNestedSubtests(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "TestA/sub.golden", "TestA/sub_nested.golden", "TestA/sub_nested_deeper.golden", "TestA/subway.golden")
+
+	moves, err := golden.PlanRename(dir, map[string]string{"TestA/sub": "TestB/renamed"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []golden.Move{
+		{From: filepath.Join(dir, "TestA/sub.golden"), To: filepath.Join(dir, "TestB/renamed.golden")},
+		{From: filepath.Join(dir, "TestA/sub_nested.golden"), To: filepath.Join(dir, "TestB/renamed_nested.golden")},
+		{From: filepath.Join(dir, "TestA/sub_nested_deeper.golden"), To: filepath.Join(dir, "TestB/renamed_nested_deeper.golden")},
+	}, moves)
+
+	_, err = golden.PlanRename(dir, map[string]string{"TestA/missing": "TestA/other"}, nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestPlanRename_FromManifest(t *testing.T) {
+	manifest := map[string]string{
+		"TestOld/sub/nested": "testdata/TestOld/sub_nested.golden",
+		"TestOld/other":      "testdata/TestOld/other.golden",
+		"TestKept":           "testdata/TestKept/TestKept.golden",
+	}
+	renames := map[string]string{"TestOld/sub": "TestOld/renamed"}
+
+	moves, err := golden.PlanRename("testdata", renames, manifest)
+	require.NoError(t, err)
+	assert.Equal(t, []golden.Move{{From: "testdata/TestOld/sub_nested.golden", To: "testdata/TestOld/renamed_nested.golden"}}, moves)
+
+	assert.Equal(t, map[string]string{
+		"TestOld/renamed/nested": "testdata/TestOld/renamed_nested.golden",
+		"TestOld/other":          "testdata/TestOld/other.golden",
+		"TestKept":               "testdata/TestKept/TestKept.golden",
+	}, golden.RenameManifest("testdata", manifest, renames))
+}
+
+func TestDiffManifests(t *testing.T) {
+	previous := map[string]string{
+		"TestOld/a":    "",
+		"TestOld/b":    "",
+		"TestX/typo":   "",
+		"TestY/first":  "",
+		"TestY/second": "",
+		"TestZ":        "",
+	}
+	current := map[string]string{
+		"TestNew/a":   "",
+		"TestNew/b":   "",
+		"TestX/fixed": "",
+		"TestY/third": "",
+		"TestY/forth": "",
+		"TestZ":       "",
+	}
+	assert.Equal(t, map[string]string{
+		"TestOld/a":  "TestNew/a",
+		"TestOld/b":  "TestNew/b",
+		"TestX/typo": "TestX/fixed",
+	}, golden.DiffManifests(previous, current))
+}
+
+func TestApplyMoves_TargetExists(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "a.golden", "b.golden")
+	err := golden.ApplyMoves([]golden.Move{{From: filepath.Join(dir, "a.golden"), To: filepath.Join(dir, "b.golden")}})
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(name), 0o600))
+	}
+}
+
+func TestRenameManifest_OverlappingRenames(t *testing.T) {
+	manifest := map[string]string{
+		"TestA/sub/x": "testdata/TestA/sub_x.golden",
+		"TestA/other": "testdata/TestA/other.golden",
+	}
+	renames := map[string]string{"TestA": "TestB", "TestA/sub": "TestC/renamed"}
+
+	for range 20 {
+		assert.Equal(t, map[string]string{
+			"TestC/renamed/x": "testdata/TestC/renamed_x.golden",
+			"TestB/other":     "testdata/TestB/other.golden",
+		}, golden.RenameManifest("testdata", manifest, renames))
+	}
+}
@@ -0,0 +1,302 @@
+package golden
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Report collects the results of the assertions of the whole run and renders the failures with side-by-side diffs
+// as Markdown or HTML, e.g. for uploading as CI artifact for the reviewers. Report is a Recorder,
+// it's safe for concurrent use and it's intended to be set up in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		report := &golden.Report{}
+//		golden.DefaultHandler.Recorders = append(golden.DefaultHandler.Recorders, report)
+//
+//		code := m.Run()
+//		if err := report.WriteFile("golden-report.html"); err != nil {
+//			log.Fatal(err)
+//		}
+//		os.Exit(code)
+//	}
+type Report struct {
+	mu      sync.Mutex
+	entries []ReportEntry
+}
+
+// ReportEntry is the result of a single assertion.
+type ReportEntry struct {
+	Test   string
+	Result Result
+}
+
+// RecordResult stores the result of the assertion.
+func (r *Report) RecordResult(test string, res Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, ReportEntry{Test: test, Result: res})
+}
+
+// Entries returns the stored results sorted by test name and golden file.
+func (r *Report) Entries() []ReportEntry {
+	r.mu.Lock()
+	entries := append([]ReportEntry(nil), r.entries...)
+	r.mu.Unlock()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Test != entries[j].Test {
+			return entries[i].Test < entries[j].Test
+		}
+		return entries[i].Result.GoldenPath < entries[j].Result.GoldenPath
+	})
+	return entries
+}
+
+// failures returns the entries of the failed assertions and the number of all assertions.
+func (r *Report) failures() ([]ReportEntry, int) {
+	entries := r.Entries()
+	var failed []ReportEntry
+	for _, e := range entries {
+		if !e.Result.Matched {
+			failed = append(failed, e)
+		}
+	}
+	return failed, len(entries)
+}
+
+// WriteFile writes the report, files with .html or .htm extension are written as HTML, files with .json extension
+// as JSON, see Report.JSON, and the others as Markdown.
+// The file is written even when there are no failures so that stale reports of previous runs don't mislead the reviewers.
+func (r *Report) WriteFile(path string) error {
+	var content string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		html, err := r.HTML()
+		if err != nil {
+			return err
+		}
+		content = html
+	case ".json":
+		b, err := r.JSON()
+		if err != nil {
+			return err
+		}
+		content = string(b)
+	default:
+		content = r.Markdown()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o600)
+}
+
+// Markdown renders the failures as Markdown with a side-by-side diff table per failure.
+func (r *Report) Markdown() string {
+	failed, total := r.failures()
+	sb := &strings.Builder{}
+	sb.WriteString("# Golden test report\n\n")
+	fmt.Fprintf(sb, "%d of %d assertions failed.\n", len(failed), total)
+	for _, e := range failed {
+		fmt.Fprintf(sb, "\n## %s\n\nGolden file: %s\n\n", e.Test, markdownCode(e.Result.GoldenPath))
+		sb.WriteString("| golden | actual |\n| --- | --- |\n")
+		for _, row := range sideBySide(e.Result.Diff) {
+			fmt.Fprintf(sb, "| %s | %s |\n", markdownCell(row.Left, row.Kind), markdownCell(row.Right, row.Kind))
+		}
+	}
+	return sb.String()
+}
+
+// HTML renders the failures as standalone HTML page with a side-by-side diff table per failure.
+func (r *Report) HTML() (string, error) {
+	failed, total := r.failures()
+	type failure struct {
+		ReportEntry
+		Rows []diffRow
+	}
+	data := struct {
+		Total    int
+		Failures []failure
+	}{Total: total}
+	for _, e := range failed {
+		data.Failures = append(data.Failures, failure{ReportEntry: e, Rows: sideBySide(e.Result.Diff)})
+	}
+
+	sb := &strings.Builder{}
+	if err := reportTemplate.Execute(sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// JSONReport is the machine-readable report written by Report.JSON, e.g. for dashboards and bots
+// which open pull requests updating the golden files.
+type JSONReport struct {
+	Total      int             `json:"total"`
+	Failed     int             `json:"failed"`
+	Assertions []JSONAssertion `json:"assertions"`
+}
+
+// JSONAssertion is the result of a single assertion in JSONReport.
+type JSONAssertion struct {
+	Test       string `json:"test"`
+	GoldenFile string `json:"golden_file"`
+	Matched    bool   `json:"matched"`
+	Recreated  bool   `json:"recreated,omitempty"`
+	// Hunks of the unified diff between the golden file and the actual content, empty when matched.
+	Hunks []DiffHunk `json:"hunks,omitempty"`
+}
+
+// DiffHunk is a hunk of unified diff.
+type DiffHunk struct {
+	// Header is the hunk header, e.g. "@@ -1,3 +1,3 @@".
+	Header string `json:"header"`
+	// Lines are the diff lines of the hunk including their " ", "-" or "+" prefixes.
+	Lines []string `json:"lines"`
+}
+
+// JSON renders all the recorded assertions as indented JSON, see JSONReport.
+func (r *Report) JSON() ([]byte, error) {
+	report := JSONReport{Assertions: []JSONAssertion{}}
+	for _, e := range r.Entries() {
+		report.Total++
+		if !e.Result.Matched {
+			report.Failed++
+		}
+		report.Assertions = append(report.Assertions, JSONAssertion{
+			Test:       e.Test,
+			GoldenFile: e.Result.GoldenPath,
+			Matched:    e.Result.Matched,
+			Recreated:  e.Result.WasRecreated,
+			Hunks:      DiffHunks(e.Result.Diff),
+		})
+	}
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// DiffHunks splits the unified diff into hunks, the file headers are dropped.
+func DiffHunks(diff string) []DiffHunk {
+	var hunks []DiffHunk
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			hunks = append(hunks, DiffHunk{Header: line, Lines: []string{}})
+		case len(hunks) > 0:
+			hunks[len(hunks)-1].Lines = append(hunks[len(hunks)-1].Lines, line)
+		}
+	}
+	return hunks
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Golden test report</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; width: 100%; table-layout: fixed; }
+td, th { border: 1px solid #ddd; padding: 0 4px; font-family: monospace; white-space: pre-wrap; vertical-align: top; }
+.hunk { background: #eef; color: #555; }
+.changed td:first-child { background: #fdd; }
+.changed td:last-child { background: #dfd; }
+</style>
+</head>
+<body>
+<h1>Golden test report</h1>
+<p>{{len .Failures}} of {{.Total}} assertions failed.</p>
+{{- range .Failures}}
+<h2>{{.Test}}</h2>
+<p>Golden file: <code>{{.Result.GoldenPath}}</code></p>
+<table>
+<tr><th>golden</th><th>actual</th></tr>
+{{- range .Rows}}
+{{- if eq .Kind '@'}}
+<tr class="hunk"><td colspan="2">{{.Left}}</td></tr>
+{{- else if eq .Kind '~'}}
+<tr class="changed"><td>{{.Left}}</td><td>{{.Right}}</td></tr>
+{{- else}}
+<tr><td>{{.Left}}</td><td>{{.Right}}</td></tr>
+{{- end}}
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// diffRow is a row of the side-by-side diff.
+type diffRow struct {
+	Left, Right string
+	// Kind is '@' for hunk headers, '~' for changed lines and ' ' for context lines.
+	Kind byte
+}
+
+// sideBySide converts the unified diff into side-by-side rows, the removed and added lines are paired in order.
+func sideBySide(diff string) []diffRow {
+	var rows []diffRow
+	var removed, added []string
+	flush := func() {
+		for i := 0; i < max(len(removed), len(added)); i++ {
+			row := diffRow{Kind: '~'}
+			if i < len(removed) {
+				row.Left = removed[i]
+			}
+			if i < len(added) {
+				row.Right = added[i]
+			}
+			rows = append(rows, row)
+		}
+		removed, added = nil, nil
+	}
+
+	inHunk := false
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case line == "", line[0] == '\\', !inHunk && !strings.HasPrefix(line, "@@"):
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			inHunk = true
+			rows = append(rows, diffRow{Left: line, Kind: '@'})
+		case line[0] == '-':
+			removed = append(removed, line[1:])
+		case line[0] == '+':
+			added = append(added, line[1:])
+		default:
+			flush()
+			rows = append(rows, diffRow{Left: line[1:], Right: line[1:], Kind: ' '})
+		}
+	}
+	flush()
+	return rows
+}
+
+func markdownCell(s string, kind byte) string {
+	if s == "" {
+		return ""
+	}
+	cell := markdownCode(strings.ReplaceAll(s, "|", `\|`))
+	if kind == '~' {
+		return "**" + cell + "**"
+	}
+	return cell
+}
+
+func markdownCode(s string) string {
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,97 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestReport", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestReport/fail.golden", []byte("a\nb|c\n-- d\ne\n"), 0o600))
+	require.NoError(t, os.WriteFile("testdata/TestReport/pass.golden", []byte("same\n"), 0o600))
+
+	report := &golden.Report{}
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		Recorders:      []golden.Recorder{report},
+	}
+	fh.Assert(&mockT{name: "TestReport/pass"}, "same\n")
+	fh.Assert(&mockT{name: "TestReport/fail"}, "a\nx<y>\ne\nf\n")
+	require.Len(t, report.Entries(), 2)
+
+	assert.Equal(t, "# Golden test report\n\n"+
+		"1 of 2 assertions failed.\n\n"+
+		"## TestReport/fail\n\n"+
+		"Golden file: `testdata/TestReport/fail.golden`\n\n"+
+		"| golden | actual |\n"+
+		"| --- | --- |\n"+
+		"| `@@ -1,4 +1,4 @@` |  |\n"+
+		"| `a` | `a` |\n"+
+		"| **`b\\|c`** | **`x<y>`** |\n"+
+		"| **`-- d`** |  |\n"+
+		"| `e` | `e` |\n"+
+		"|  | **`f`** |\n", report.Markdown())
+
+	html, err := report.HTML()
+	require.NoError(t, err)
+	assert.Contains(t, html, "<p>1 of 2 assertions failed.</p>")
+	assert.Contains(t, html, `<tr class="hunk"><td colspan="2">@@ -1,4 &#43;1,4 @@</td></tr>`)
+	assert.Contains(t, html, `<tr class="changed"><td>b|c</td><td>x&lt;y&gt;</td></tr>`)
+	assert.Contains(t, html, `<tr><td>e</td><td>e</td></tr>`)
+
+	require.NoError(t, report.WriteFile(filepath.Join("out", "report.html")))
+	b, err := os.ReadFile("out/report.html")
+	require.NoError(t, err)
+	assert.Equal(t, html, string(b))
+
+	require.NoError(t, report.WriteFile("report.md"))
+	b, err = os.ReadFile("report.md")
+	require.NoError(t, err)
+	assert.Equal(t, report.Markdown(), string(b))
+}
+
+func TestReport_JSON(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestReport", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestReport/fail.golden", []byte("a\nb\n"), 0o600))
+
+	report := &golden.Report{}
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(t golden.T) bool { return t.Name() == "TestReport/new" },
+		Equal:          golden.EqualWithDiff,
+		Recorders:      []golden.Recorder{report},
+	}
+	fh.Assert(&mockT{name: "TestReport/new"}, "new\n")
+	fh.Assert(&mockT{name: "TestReport/fail"}, "a\nc\n")
+
+	require.NoError(t, report.WriteFile("report.json"))
+	b, err := os.ReadFile("report.json")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"total": 2,
+		"failed": 1,
+		"assertions": [
+			{
+				"test": "TestReport/fail",
+				"golden_file": "testdata/TestReport/fail.golden",
+				"matched": false,
+				"hunks": [{"header": "@@ -1,2 +1,2 @@", "lines": [" a", "-b", "+c"]}]
+			},
+			{
+				"test": "TestReport/new",
+				"golden_file": "testdata/TestReport/new.golden",
+				"matched": true,
+				"recreated": true
+			}
+		]
+	}`, string(b))
+}
@@ -0,0 +1,63 @@
+package golden
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RetryPolicy retries the requests which fail with a transport error or with one of the retryable status codes,
+// see FileHandler.Retry. The zero value doesn't retry.
+type RetryPolicy struct {
+	// Attempts is the maximum number of retries after the first attempt.
+	Attempts int
+	// Backoff is the delay before the first retry, it's doubled after each retry.
+	Backoff time.Duration
+	// StatusCodes are the response status codes which are retried, e.g. http.StatusServiceUnavailable.
+	// Only transport errors are retried when empty.
+	StatusCodes []int
+}
+
+// do sends the request retrying it according to the policy, the response of the last attempt is returned.
+// Requests with a body are retried only when the body can be recreated with GetBody, like for http.NewRequest with
+// bytes.Reader, bytes.Buffer or strings.Reader body.
+func (p RetryPolicy) do(t T, client Client, req *http.Request) (*http.Response, error) {
+	backoff := p.Backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := client.Do(req)
+		if attempt >= p.Attempts || !p.retryable(resp, err) || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		if err != nil {
+			t.Logf("request %s %s failed, retrying in %s: %s", req.Method, req.URL, backoff, err)
+		} else {
+			t.Logf("request %s %s returned status code %d, retrying in %s", req.Method, req.URL, resp.StatusCode, backoff)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+func (p RetryPolicy) retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return slices.Contains(p.StatusCodes, resp.StatusCode)
+}
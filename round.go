@@ -0,0 +1,65 @@
+package golden
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RoundFloats returns ProcessContent function which rounds the fractional numbers of the JSON to the given number
+// of decimals, so output with floating-point jitter across platforms, e.g. scientific or metrics data, can be compared.
+// Integers, strings and the formatting of the JSON are kept as is, e.g. combine it with PrettyJSON.
+func RoundFloats(precision int) func(T, string) string {
+	return func(t T, data string) string {
+		if !json.Valid([]byte(data)) {
+			NoError(t, errors.New("invalid JSON"), "failed to round floats")
+			return data
+		}
+
+		sb := &strings.Builder{}
+		for i := 0; i < len(data); {
+			c := data[i]
+			switch {
+			case c == '"':
+				end := i + 1
+				for data[end] != '"' {
+					if data[end] == '\\' {
+						end++
+					}
+					end++
+				}
+				sb.WriteString(data[i : end+1])
+				i = end + 1
+			case c == '-' || (c >= '0' && c <= '9'):
+				end := i + 1
+				for end < len(data) && strings.IndexByte("0123456789.eE+-", data[end]) >= 0 {
+					end++
+				}
+				sb.WriteString(roundJSONNumber(data[i:end], precision))
+				i = end
+			default:
+				sb.WriteByte(c)
+				i++
+			}
+		}
+		return sb.String()
+	}
+}
+
+// roundJSONNumber rounds the number when it has fractional part or exponent, the numbers which can't be rounded
+// without overflowing are kept as is.
+func roundJSONNumber(num string, precision int) string {
+	if !strings.ContainsAny(num, ".eE") {
+		return num
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return num
+	}
+	if scaled := f * math.Pow(10, float64(precision)); math.IsInf(scaled, 0) || math.IsNaN(scaled) {
+		return num
+	}
+	return roundFloat(f, precision, strings.ContainsAny(num, "eE"))
+}
@@ -0,0 +1,31 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundFloats(t *testing.T) {
+	round := golden.RoundFloats(3)
+	for in, expected := range map[string]string{
+		`{"mean": 0.30000000000000004, "count": 12345678901234567890}`: `{"mean": 0.3, "count": 12345678901234567890}`,
+		`[1.23456, -0.0001, 2.5e-7, 1E2, "3.14159", "a\"1.23456"]`:     `[1.235, 0, 0, 100, "3.14159", "a\"1.23456"]`,
+		"{\n  \"p99\": 12.3456789\n}\n":                                "{\n  \"p99\": 12.346\n}\n",
+		`[1e308]`:                                                      `[1e308]`,
+		`[1e300, 6.02214076e23, -1.5E+20, 1.23456e-2]`:                 `[1e+300, 6.02214076e+23, -1.5e+20, 0.012]`,
+	} {
+		mt := &mockT{}
+		assert.Equal(t, expected, round(mt, in), in)
+		assert.False(t, mt.failed)
+	}
+
+	linux := golden.PrettyJSON(&mockT{}, round(&mockT{}, `{"x": 0.1234567891}`))
+	mac := golden.PrettyJSON(&mockT{}, round(&mockT{}, `{"x":0.1234567893}`))
+	assert.Equal(t, linux, mac)
+
+	mt := &mockT{}
+	assert.Equal(t, "{", round(mt, "{"))
+	assert.Contains(t, mt.msg, "invalid JSON")
+}
@@ -0,0 +1,118 @@
+package golden
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// RunDir runs a subtest for each input file in the directory, including the subdirectories, and checks the output
+// of fn against the sibling golden file, which has the same name with .golden extension instead of the input's extension,
+// e.g. testdata/cases/nested.input is compared against testdata/cases/nested.golden. The subtests are named by the
+// input paths relative to the directory. This is the classic input/output fixture pattern, adding a case is adding a file:
+//
+//	golden.RunDir(t, "testdata/cases", func(t *testing.T, input []byte) []byte {
+//		out, err := format.Source(input)
+//		require.NoError(t, err)
+//		return out
+//	})
+//
+// The inputs sharing the name without the extension, e.g. a.txt and a.json, would share the golden file,
+// so RunDir fails without running the subtests.
+func RunDir(t RunT, dir string, fn func(t *testing.T, input []byte) []byte) {
+	Default().RunDir(t, dir, fn)
+}
+
+// RunT is T which runs subtests, e.g. *testing.T.
+type RunT interface {
+	T
+	Run(name string, f func(t *testing.T)) bool
+}
+
+func (h *FileHandler) RunDir(t RunT, dir string, fn func(t *testing.T, input []byte) []byte) {
+	t.Helper()
+	inputs, err := dirInputs(dir)
+	NoError(t, err, "failed to list inputs")
+	if len(inputs) == 0 {
+		t.Errorf("no input files in %s", dir)
+		return
+	}
+
+	goldenFiles := map[string]string{}
+	for _, input := range inputs {
+		goldenFile := strings.TrimSuffix(input, filepath.Ext(input)) + ".golden"
+		if other, ok := goldenFiles[goldenFile]; ok {
+			t.Errorf("inputs %s and %s share the golden file %s, rename one of them", other, input, goldenFile)
+			t.FailNow()
+			return
+		}
+		goldenFiles[goldenFile] = input
+	}
+
+	for _, input := range inputs {
+		name, _ := filepath.Rel(dir, input)
+		t.Run(filepath.ToSlash(name), func(t *testing.T) {
+			data, err := os.ReadFile(input)
+			NoError(t, err, "failed to read input")
+
+			c := *h
+			goldenFile := strings.TrimSuffix(input, filepath.Ext(input)) + ".golden"
+			c.FileName = func(T) string { return goldenFile }
+			c.Assert(t, string(fn(t, data)))
+		})
+	}
+}
+
+// dirInputs returns the sorted paths of the files in the directory which aren't golden files or their artifacts.
+func dirInputs(dir string) ([]string, error) {
+	var inputs []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if !isGoldenArtifact(path) {
+			inputs = append(inputs, path)
+		}
+		return nil
+	})
+	sort.Strings(inputs)
+	return inputs, err
+}
+
+// goldenArtifactSuffixes are the suffixes of the golden files and the files the package writes next to them.
+var goldenArtifactSuffixes = []string{".golden", ".diff", PendingSuffix, ".actual.png", ".diff.png"}
+
+func isGoldenArtifact(path string) bool {
+	for _, suffix := range goldenArtifactSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Input returns the content of the input file paired with the golden file of the test, it has the same name
+// with .input extension, e.g. testdata/TestParse/valid.input for testdata/TestParse/valid.golden.
+// This keeps the input and the expected output of the transformation pipelines together:
+//
+//	out := transform(golden.Input(t))
+//	golden.Assert(t, out)
+func Input(t T) []byte {
+	return Default().Input(t)
+}
+
+func (h *FileHandler) Input(t T) []byte {
+	t.Helper()
+	name := InputFileName(h.FileName(t))
+	b, err := h.readFile(name, false)
+	NoError(t, err, "failed to read input file")
+	return b
+}
+
+// InputFileName returns the name of the input file paired with the golden file, see Input.
+func InputFileName(goldenFile string) string {
+	return strings.TrimSuffix(goldenFile, filepath.Ext(goldenFile)) + ".input"
+}
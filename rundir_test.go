@@ -0,0 +1,68 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDir(t *testing.T) {
+	var names []string
+	golden.RunDir(t, "testdata/TestRunDir", func(t *testing.T, input []byte) []byte {
+		names = append(names, t.Name())
+		return []byte(strings.ToUpper(string(input)))
+	})
+	assert.Equal(t, []string{"TestRunDir/greeting.txt", "TestRunDir/nested/other.input"}, names)
+}
+
+// runMockT is mockT which counts the subtests instead of running them.
+type runMockT struct {
+	*mockT
+	runs int
+}
+
+func (m *runMockT) Run(string, func(t *testing.T)) bool {
+	m.runs++
+	return true
+}
+
+func TestRunDir_SharedGoldenFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte("{}"), 0o600))
+
+	mt := &runMockT{mockT: &mockT{name: "TestRunDir"}}
+	golden.RunDir(mt, dir, func(_ *testing.T, input []byte) []byte { return input })
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "inputs "+filepath.Join(dir, "a.json")+" and "+filepath.Join(dir, "a.txt")+" share the golden file "+filepath.Join(dir, "a.golden"))
+	assert.Zero(t, mt.runs)
+}
+
+func TestInput(t *testing.T) {
+	lines := strings.Fields(string(golden.Input(t)))
+	sort.Strings(lines)
+	golden.Assert(t, strings.Join(lines, "\n")+"\n")
+
+	mt := &mockT{name: "TestInputMissing"}
+	assert.Nil(t, golden.Input(mt))
+	assert.True(t, mt.failed)
+	assert.Equal(t, "testdata/x/y.input", golden.InputFileName("testdata/x/y.golden"))
+}
+
+func TestRunDir_SkipsArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "a.golden", "a.golden.pending", "a.golden.diff", "a.actual.png", "a.diff.png"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("a"), 0o600))
+	}
+
+	mt := &runMockT{mockT: &mockT{name: "TestRunDir"}}
+	golden.RunDir(mt, dir, func(_ *testing.T, input []byte) []byte { return input })
+	assert.False(t, mt.failed, mt.msg)
+	assert.Equal(t, 1, mt.runs)
+}
@@ -0,0 +1,50 @@
+package golden
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PlaceholderStyle formats the placeholder which replaces the scrubbed value, name is the upper case name of the scrubber.
+type PlaceholderStyle func(name string) string
+
+var (
+	// AngleBrackets renders placeholders like <<UUID>>, it's the default style.
+	AngleBrackets PlaceholderStyle = func(name string) string { return "<<" + name + ">>" }
+	// CurlyBraces renders placeholders like {{uuid}}.
+	CurlyBraces PlaceholderStyle = func(name string) string { return "{{" + strings.ToLower(name) + "}}" }
+	// SquareBrackets renders placeholders like [UUID].
+	SquareBrackets PlaceholderStyle = func(name string) string { return "[" + name + "]" }
+)
+
+// Scrubber replaces unstable values, like generated IDs and timestamps, with a placeholder
+// so that they don't cause differences between test runs.
+type Scrubber struct {
+	// Name is used for the placeholder, e.g. "UUID" is rendered as <<UUID>> using AngleBrackets style.
+	Name string
+	// Pattern matches the values to be replaced.
+	Pattern *regexp.Regexp
+}
+
+var (
+	// UUIDScrubber replaces UUIDs.
+	UUIDScrubber = Scrubber{Name: "UUID", Pattern: regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)}
+	// TimestampScrubber replaces RFC 3339 timestamps.
+	TimestampScrubber = Scrubber{Name: "TIMESTAMP", Pattern: regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})?`)}
+)
+
+// Scrub returns ProcessContent function which replaces the values matched by the scrubbers with placeholders
+// rendered with the given style, AngleBrackets is used when style is nil.
+// Choose a style which doesn't collide with the content under test, e.g. CurlyBraces can't be used for Go templates.
+func Scrub(style PlaceholderStyle, scrubbers ...Scrubber) func(T, string) string {
+	if style == nil {
+		style = AngleBrackets
+	}
+
+	return func(_ T, data string) string {
+		for _, s := range scrubbers {
+			data = s.Pattern.ReplaceAllLiteralString(data, style(s.Name))
+		}
+		return data
+	}
+}
@@ -0,0 +1,48 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrub(t *testing.T) {
+	const data = `{"id": "0b5f8a3e-7c1d-4f5e-9a2b-3c4d5e6f7a8b", "created_at": "2024-01-02T03:04:05.123Z", "template": "{{ .Name }}"}`
+
+	tests := []struct {
+		name     string
+		style    golden.PlaceholderStyle
+		expected string
+	}{
+		{name: "default", style: nil, expected: `{"id": "<<UUID>>", "created_at": "<<TIMESTAMP>>", "template": "{{ .Name }}"}`},
+		{name: "curly", style: golden.CurlyBraces, expected: `{"id": "{{uuid}}", "created_at": "{{timestamp}}", "template": "{{ .Name }}"}`},
+		{name: "square", style: golden.SquareBrackets, expected: `{"id": "[UUID]", "created_at": "[TIMESTAMP]", "template": "{{ .Name }}"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := golden.Scrub(tt.style, golden.UUIDScrubber, golden.TimestampScrubber)(t, data)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestScrubbers(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestScrubbers"), "failed to remove testdata") })
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Scrubbers:      []golden.Scrubber{golden.UUIDScrubber},
+		Placeholder:    golden.SquareBrackets,
+	}
+
+	mt := &mockT{name: "TestScrubbers"}
+	assert.True(t, fh.Assert(mt, "id: 0B5F8A3E-7C1D-4F5E-9A2B-3C4D5E6F7A8B"))
+	b, err := os.ReadFile("./testdata/TestScrubbers/TestScrubbers.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "id: [UUID]", string(b))
+}
@@ -0,0 +1,113 @@
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// SelfCheckRules configures the invariants verified by SelfCheckWith.
+type SelfCheckRules struct {
+	// SkipExts lists file extensions which are not checked, e.g. binary goldens and failure artifacts.
+	SkipExts []string
+	// AllowTrailingWhitespace disables the trailing whitespace check.
+	AllowTrailingWhitespace bool
+	// AllowCRLF disables the LF line ending check.
+	AllowCRLF bool
+}
+
+// DefaultSelfCheckRules skips images and the failure artifacts written next to the golden files.
+var DefaultSelfCheckRules = SelfCheckRules{
+	SkipExts: []string{".png", ".gif", ".jpg", ".jpeg", ".bin", ".diff", ".actual", ".pending"},
+}
+
+// SelfCheck verifies the structural invariants of the golden files in the dir using DefaultSelfCheckRules.
+// It's meant to be used as a lint-like test which keeps the fixture quality consistent across contributors:
+//
+//	func TestGoldenFiles(t *testing.T) {
+//		golden.SelfCheck(t, "testdata")
+//	}
+func SelfCheck(t T, dir string) bool {
+	return SelfCheckWith(t, dir, DefaultSelfCheckRules)
+}
+
+// SelfCheckWith walks the dir and reports every file which is not valid UTF-8, contains CRLF line endings
+// or trailing whitespace, and every .json or .json.golden file which is not valid JSON.
+func SelfCheckWith(t T, dir string, rules SelfCheckRules) bool {
+	t.Helper()
+	ok := true
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || rules.skip(path) {
+			return err
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		for _, problem := range rules.check(path, b) {
+			ok = false
+			t.Errorf("%s: %s", path, problem)
+		}
+		return nil
+	})
+	NoError(t, err, "failed to check golden files")
+	return ok && err == nil
+}
+
+func (r SelfCheckRules) skip(path string) bool {
+	for _, ext := range r.SkipExts {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r SelfCheckRules) check(path string, b []byte) []string {
+	if !utf8.Valid(b) {
+		return []string{"invalid UTF-8"}
+	}
+
+	var problems []string
+	if !r.AllowCRLF && bytes.Contains(b, []byte("\r\n")) {
+		problems = append(problems, "CRLF line endings, use LF")
+	}
+
+	if !r.AllowTrailingWhitespace {
+		for i, line := range strings.Split(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n") {
+			if strings.TrimRight(line, " \t") != line {
+				problems = append(problems, fmt.Sprintf("line %d: trailing whitespace", i+1))
+			}
+		}
+	}
+
+	if strings.HasSuffix(path, ".json") || strings.HasSuffix(path, ".json.golden") {
+		if err := validateJSONStream(b); err != nil {
+			problems = append(problems, "invalid JSON: "+err.Error())
+		}
+	}
+	return problems
+}
+
+func validateJSONStream(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	for {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
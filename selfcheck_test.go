@@ -0,0 +1,56 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfCheck(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"TestOK/ok.golden":            "line 1\nline 2\n",
+		"TestOK/ok.json.golden":       "{\"a\": 1}\n{\"b\": 2}\n",
+		"TestOK/image.png":            "\xff\xfe binary ",
+		"TestOK/ok.golden.diff":       "--- a\n+++ b\n \n",
+		"TestBad/whitespace.golden":   "ok\ntrailing \nok",
+		"TestBad/crlf.golden":         "a\r\nb",
+		"TestBad/binary.golden":       "\xff\xfe",
+		"TestBad/invalid.json":        `{"a": }`,
+		"TestBad/invalid.json.golden": "}",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	mt := &mockT{name: "TestSelfCheck"}
+	assert.False(t, golden.SelfCheck(mt, dir))
+	assert.True(t, mt.failed)
+	for _, expected := range []string{
+		filepath.Join(dir, "TestBad/whitespace.golden") + ": line 2: trailing whitespace",
+		filepath.Join(dir, "TestBad/crlf.golden") + ": CRLF line endings, use LF",
+		filepath.Join(dir, "TestBad/binary.golden") + ": invalid UTF-8",
+		filepath.Join(dir, "TestBad/invalid.json") + ": invalid JSON",
+		filepath.Join(dir, "TestBad/invalid.json.golden") + ": invalid JSON",
+	} {
+		assert.Contains(t, mt.msg, expected)
+	}
+	assert.NotContains(t, mt.msg, "TestOK")
+
+	mt = &mockT{name: "TestSelfCheck"}
+	assert.True(t, golden.SelfCheck(mt, filepath.Join(dir, "TestOK")))
+	assert.False(t, mt.failed)
+}
+
+func TestGoldenFiles(t *testing.T) {
+	golden.SelfCheckWith(t, "testdata", golden.SelfCheckRules{
+		SkipExts:                append(golden.DefaultSelfCheckRules.SkipExts, "binary.golden"),
+		AllowTrailingWhitespace: false,
+	})
+}
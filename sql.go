@@ -0,0 +1,84 @@
+package golden
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NullValue is used for rendering SQL NULL values by AssertRows.
+const NullValue = "NULL"
+
+// AssertRows renders the query result using RowsToCSV and checks the golden file content against it.
+// Rows are closed after rendering.
+func AssertRows(t T, rows *sql.Rows) bool {
+	return Default().AssertRows(t, rows)
+}
+
+func (h *FileHandler) AssertRows(t T, rows *sql.Rows) bool {
+	t.Helper()
+	data, err := RowsToCSV(rows)
+	if err != nil {
+		NoError(t, err, "failed to read rows")
+		return false
+	}
+	return h.Assert(t, data)
+}
+
+// RowsToCSV renders the query result as CSV with the column names on the first line.
+// Values are rendered deterministically: NULL as NullValue, []byte as string and time.Time in RFC 3339 format with nanoseconds.
+// Rows are closed after rendering.
+func RowsToCSV(rows *sql.Rows) (string, error) {
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	sb := &strings.Builder{}
+	w := csv.NewWriter(sb)
+	if err := w.Write(cols); err != nil {
+		return "", err
+	}
+
+	values := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	record := make([]string, len(cols))
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return "", err
+		}
+		for i, v := range values {
+			record[i] = formatSQLValue(v)
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	w.Flush()
+	return sb.String(), w.Error()
+}
+
+func formatSQLValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return NullValue
+	case []byte:
+		return string(x)
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(x)
+	}
+}
@@ -0,0 +1,62 @@
+package golden_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertRows(t *testing.T) {
+	db := sql.OpenDB(rowsConnector{
+		columns: []string{"id", "name", "created_at", "deleted_at", "data"},
+		rows: [][]driver.Value{
+			{int64(1), "someone", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), nil, []byte("raw")},
+			{int64(2), "someone, else", time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), []byte(`"quoted"`)},
+		},
+	})
+	t.Cleanup(func() { assert.NoError(t, db.Close()) })
+
+	rows, err := db.Query("SELECT * FROM users")
+	require.NoError(t, err)
+	golden.AssertRows(t, rows)
+}
+
+// rowsConnector is a minimal database/sql driver which returns the same rows for every query.
+type rowsConnector struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (c rowsConnector) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c rowsConnector) Driver() driver.Driver                        { return nil }
+func (c rowsConnector) Prepare(string) (driver.Stmt, error)          { return c, nil }
+func (c rowsConnector) Close() error                                 { return nil }
+func (c rowsConnector) Begin() (driver.Tx, error)                    { return nil, driver.ErrSkip }
+func (c rowsConnector) NumInput() int                                { return -1 }
+func (c rowsConnector) Exec([]driver.Value) (driver.Result, error)   { return nil, driver.ErrSkip }
+func (c rowsConnector) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{columns: c.columns, rows: c.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,268 @@
+package golden
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SQLOptions configures NormalizeSQL.
+type SQLOptions struct {
+	// SortInsertColumns sorts the column lists of INSERT statements by name and reorders the VALUES rows accordingly,
+	// e.g. for query builders iterating over maps. Statements whose rows don't match the column list are kept as is.
+	SortInsertColumns bool
+}
+
+// NormalizeSQL returns ProcessContent function which normalizes SQL statements, so query builder output
+// and generated migrations can be compared without whitespace churn:
+// whitespace is collapsed to single spaces, spaces inside parentheses and before commas are removed,
+// comparison operators are surrounded by spaces,
+// keywords are upper cased and each statement is placed on its own line.
+// String literals, quoted identifiers and comments are kept as is.
+func NormalizeSQL(opts SQLOptions) func(T, string) string {
+	return func(_ T, data string) string {
+		tokens := tokenizeSQL(data)
+		if opts.SortInsertColumns {
+			tokens = sortInsertColumns(tokens)
+		}
+		return renderSQL(tokens)
+	}
+}
+
+type sqlToken struct {
+	text string
+	// space tells whether the token was preceded by whitespace.
+	space bool
+}
+
+func (t sqlToken) is(text string) bool {
+	return strings.EqualFold(t.text, text)
+}
+
+// tokenizeSQL splits the SQL into words, quoted strings, comments and punctuation, keywords are upper cased.
+func tokenizeSQL(src string) []sqlToken {
+	var tokens []sqlToken
+	space := false
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+			i++
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			end := i + 1
+			for end < len(src) {
+				if src[end] == c {
+					// Doubled quote is an escaped quote.
+					if end+1 < len(src) && src[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			end = min(end+1, len(src))
+			tokens = append(tokens, sqlToken{text: src[i:end], space: space})
+			i = end
+		case strings.HasPrefix(src[i:], "--"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			tokens = append(tokens, sqlToken{text: strings.TrimRight(src[i:i+end], " \t\r"), space: space})
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src) - i
+			} else {
+				end += 4
+			}
+			tokens = append(tokens, sqlToken{text: src[i : i+end], space: space})
+			i += end
+		case isSQLWordByte(c):
+			end := i
+			for end < len(src) && isSQLWordByte(src[end]) {
+				end++
+			}
+			word := src[i:end]
+			if sqlKeywords[strings.ToUpper(word)] {
+				word = strings.ToUpper(word)
+			}
+			tokens = append(tokens, sqlToken{text: word, space: space})
+			i = end
+		case isSQLOperatorByte(c):
+			end := i
+			for end < len(src) && isSQLOperatorByte(src[end]) {
+				end++
+			}
+			tokens = append(tokens, sqlToken{text: src[i:end], space: true})
+			i = end
+		default:
+			tokens = append(tokens, sqlToken{text: string(c), space: space})
+			i++
+		}
+		space = false
+	}
+	return tokens
+}
+
+func isSQLOperatorByte(c byte) bool {
+	return strings.IndexByte("<>=!|", c) >= 0
+}
+
+func isSQLWordByte(c byte) bool {
+	return c == '_' || c == '$' || c == '@' || c == '#' || c == '?' || c == ':' || c >= 0x80 ||
+		unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+// renderSQL joins the tokens with single spaces, spaces are dropped after opening parentheses, before closing
+// parentheses, commas and semicolons, and around dots. Commas and comparison operators are followed by a space
+// and statements by a newline.
+func renderSQL(tokens []sqlToken) string {
+	sb := &strings.Builder{}
+	lineStart := true
+	for i, tok := range tokens {
+		if !lineStart {
+			prev := tokens[i-1].text
+			switch {
+			case tok.text == ")" || tok.text == "," || tok.text == ";" || tok.text == ".":
+			case prev == "(" || prev == ".":
+			case prev == ",":
+				sb.WriteByte(' ')
+			case tok.space, isSQLOperatorByte(prev[0]):
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteString(tok.text)
+		lineStart = tok.text == ";" || strings.HasPrefix(tok.text, "--")
+		if lineStart {
+			sb.WriteByte('\n')
+		}
+	}
+	if !lineStart {
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// sortInsertColumns sorts the columns of INSERT INTO name (columns) VALUES (row), ... statements.
+func sortInsertColumns(tokens []sqlToken) []sqlToken {
+	for i := range tokens {
+		if !tokens[i].is("INSERT") || i+3 >= len(tokens) || !tokens[i+1].is("INTO") {
+			continue
+		}
+
+		open := i + 2
+		for open < len(tokens) && tokens[open].text != "(" && tokens[open].text != ";" && !tokens[open].is("VALUES") {
+			open++
+		}
+		if open >= len(tokens) || tokens[open].text != "(" {
+			continue
+		}
+		cols, end := sqlGroups(tokens, open)
+		if end+1 >= len(tokens) || !tokens[end+1].is("VALUES") {
+			continue
+		}
+
+		var rows [][][]sqlToken
+		var rowStarts []int
+		pos := end + 2
+		for pos < len(tokens) && tokens[pos].text == "(" {
+			row, rowEnd := sqlGroups(tokens, pos)
+			rows, rowStarts = append(rows, row), append(rowStarts, pos)
+			pos = rowEnd + 1
+			if pos >= len(tokens) || tokens[pos].text != "," {
+				break
+			}
+			pos++
+		}
+
+		consistent := len(rows) > 0
+		for _, row := range rows {
+			consistent = consistent && len(row) == len(cols)
+		}
+		if !consistent {
+			continue
+		}
+
+		order := make([]int, len(cols))
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return sqlColumnName(cols[order[a]]) < sqlColumnName(cols[order[b]])
+		})
+
+		sorted := append([]sqlToken(nil), tokens[:open]...)
+		sorted = append(sorted, permuteGroups(cols, order, tokens[open].space)...)
+		sorted = append(sorted, tokens[end+1])
+		for r, row := range rows {
+			if r > 0 {
+				sorted = append(sorted, sqlToken{text: ","})
+			}
+			sorted = append(sorted, permuteGroups(row, order, tokens[rowStarts[r]].space)...)
+		}
+		tokens = append(sorted, tokens[pos:]...)
+	}
+	return tokens
+}
+
+// sqlGroups splits the tokens of the parenthesized list starting at open into its top-level comma separated items
+// and returns them with the index of the closing parenthesis.
+func sqlGroups(tokens []sqlToken, open int) ([][]sqlToken, int) {
+	var groups [][]sqlToken
+	var current []sqlToken
+	depth := 0
+	for i := open + 1; i < len(tokens); i++ {
+		switch tok := tokens[i]; {
+		case tok.text == "(":
+			depth++
+		case tok.text == ")" && depth == 0:
+			return append(groups, current), i
+		case tok.text == ")":
+			depth--
+		case tok.text == "," && depth == 0:
+			groups, current = append(groups, current), nil
+			continue
+		}
+		current = append(current, tokens[i])
+	}
+	return append(groups, current), len(tokens) - 1
+}
+
+func permuteGroups(groups [][]sqlToken, order []int, space bool) []sqlToken {
+	out := []sqlToken{{text: "(", space: space}}
+	for i, j := range order {
+		if i > 0 {
+			out = append(out, sqlToken{text: ","})
+		}
+		out = append(out, groups[j]...)
+	}
+	return append(out, sqlToken{text: ")"})
+}
+
+func sqlColumnName(tokens []sqlToken) string {
+	var parts []string
+	for _, tok := range tokens {
+		parts = append(parts, strings.ToLower(strings.Trim(tok.text, "\"`")))
+	}
+	return strings.Join(parts, "")
+}
+
+var sqlKeywords = map[string]bool{}
+
+func init() {
+	for _, kw := range strings.Fields(`
+		ADD ALL ALTER AND ANY AS ASC BEGIN BETWEEN BY CASCADE CASE CAST CHECK COLUMN COMMIT CONFLICT CONSTRAINT
+		CREATE CROSS CURRENT_DATE CURRENT_TIMESTAMP DEFAULT DELETE DESC DISTINCT DO DROP ELSE END EXCEPT EXISTS
+		FALSE FETCH FIRST FOREIGN FROM FULL GROUP HAVING IF ILIKE IN INDEX INNER INSERT INTERSECT INTO IS JOIN KEY
+		LEFT LIKE LIMIT NOT NOTHING NULL OFFSET ON OR ORDER OUTER OVER PARTITION PRIMARY REFERENCES RETURNING
+		RIGHT ROLLBACK ROWS SELECT SET TABLE THEN TO TRANSACTION TRUE TRUNCATE UNION UNIQUE UPDATE USING VALUES
+		VIEW WHEN WHERE WITH
+	`) {
+		sqlKeywords[kw] = true
+	}
+}
@@ -0,0 +1,34 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	normalize := golden.NormalizeSQL(golden.SQLOptions{})
+	for in, expected := range map[string]string{
+		"select  id,name\n  from users\twhere id = $1":              "SELECT id, name FROM users WHERE id = $1\n",
+		"SELECT count( * ) FROM t ; delete from t where x<>'a  b'":  "SELECT count(*) FROM t;\nDELETE FROM t WHERE x <> 'a  b'\n",
+		"select \"Select\" , 'it''s' from s.t -- note\nwhere a>=1":  "SELECT \"Select\", 'it''s' FROM s.t -- note\nWHERE a >= 1\n",
+		"insert into t (b,a) values (2,1)":                          "INSERT INTO t (b, a) VALUES (2, 1)\n",
+		"create table t(\n  id int primary key,\n  name text\n);\n": "CREATE TABLE t(id int PRIMARY KEY, name text);\n",
+		"select a /* keep  this */ from t":                          "SELECT a /* keep  this */ FROM t\n",
+	} {
+		assert.Equal(t, expected, normalize(&mockT{}, in), in)
+	}
+}
+
+func TestNormalizeSQL_SortInsertColumns(t *testing.T) {
+	normalize := golden.NormalizeSQL(golden.SQLOptions{SortInsertColumns: true})
+	for in, expected := range map[string]string{
+		"insert into t (c, a, b) values (3, 1, 2), (now(), 'x', lower('Y'))":  "INSERT INTO t (a, b, c) VALUES (1, 2, 3), ('x', lower('Y'), now())\n",
+		`INSERT INTO t ("B", a) VALUES ($1, $2) RETURNING id`:                 `INSERT INTO t (a, "B") VALUES ($2, $1) RETURNING id` + "\n",
+		"insert into t (b, a) values (1); insert into u (d, c) values (4, 3)": "INSERT INTO t (b, a) VALUES (1);\nINSERT INTO u (c, d) VALUES (3, 4)\n",
+		"insert into t (b, a) select b, a from u":                             "INSERT INTO t (b, a) SELECT b, a FROM u\n",
+	} {
+		assert.Equal(t, expected, normalize(&mockT{}, in), in)
+	}
+}
@@ -0,0 +1,125 @@
+package golden
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SSEOptions limits how long RequestSSE reads the event stream.
+type SSEOptions struct {
+	// MaxEvents stops reading after the given number of events, the stream is read until it's closed when zero.
+	MaxEvents int
+	// Timeout stops reading after the given duration, the events received so far are asserted.
+	Timeout time.Duration
+}
+
+// SSEEvent is a single Server-Sent Event, the fields not sent by the server are empty.
+type SSEEvent struct {
+	ID    string
+	Event string
+	Data  string
+	Retry string
+}
+
+// RequestSSE sends the request and reads the text/event-stream response until the stream is closed
+// or the limits in opts are hit. It asserts that the response status code is equal to the expectedStatusCode
+// and that the events rendered by SSETranscript are equal to the golden file content.
+func RequestSSE(t T, client Client, req *http.Request, expectedStatusCode int, opts SSEOptions) ([]SSEEvent, bool) {
+	return Default().RequestSSE(t, client, req, expectedStatusCode, opts)
+}
+
+func (h *FileHandler) RequestSSE(t T, client Client, req *http.Request, expectedStatusCode int, opts SSEOptions) ([]SSEEvent, bool) {
+	t.Helper()
+	if opts.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), opts.Timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := h.Retry.do(t, client, req)
+	NoError(t, err, "client.Do failed")
+	defer resp.Body.Close()
+
+	if h.RequestRecorder != nil {
+		h.RequestRecorder.Record(RequestRecord{Test: t.Name(), Method: req.Method, Path: req.URL.Path, StatusCode: resp.StatusCode})
+	}
+
+	ok := true
+	if resp.StatusCode != expectedStatusCode {
+		ok = false
+		t.Errorf("expected status code %d, got %d", expectedStatusCode, resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); resp.StatusCode == expectedStatusCode && mediaType != "text/event-stream" {
+		ok = false
+		t.Errorf("expected Content-Type text/event-stream, got %q", resp.Header.Get("Content-Type"))
+	}
+
+	var events []SSEEvent
+	ev, hasFields := SSEEvent{}, false
+	scanner := bufio.NewScanner(resp.Body)
+	for (opts.MaxEvents <= 0 || len(events) < opts.MaxEvents) && scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if hasFields {
+				events = append(events, ev)
+			}
+			ev, hasFields = SSEEvent{}, false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			ev.ID = value
+		case "event":
+			ev.Event = value
+		case "data":
+			if hasFields && ev.Data != "" {
+				ev.Data += "\n"
+			}
+			ev.Data += value
+		case "retry":
+			ev.Retry = value
+		default:
+			continue
+		}
+		hasFields = true
+	}
+	// Scanner errors are expected when the timeout cancels the request, unterminated last event is dropped like browsers do.
+
+	return events, h.Assert(t, SSETranscript(events)) && ok
+}
+
+// SSETranscript renders the events in order, each preceded by a header line with its sequence number.
+// Only the fields set on the event are rendered, multi-line data is rendered as multiple data fields.
+func SSETranscript(events []SSEEvent) string {
+	sb := &strings.Builder{}
+	for i, ev := range events {
+		fmt.Fprintf(sb, "--- event %d\n", i+1)
+		if ev.Event != "" {
+			fmt.Fprintf(sb, "event: %s\n", ev.Event)
+		}
+		if ev.ID != "" {
+			fmt.Fprintf(sb, "id: %s\n", ev.ID)
+		}
+		if ev.Retry != "" {
+			fmt.Fprintf(sb, "retry: %s\n", ev.Retry)
+		}
+		if ev.Data == "" {
+			continue
+		}
+		for _, line := range strings.Split(ev.Data, "\n") {
+			fmt.Fprintf(sb, "data: %s\n", line)
+		}
+	}
+	return sb.String()
+}
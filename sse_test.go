@@ -0,0 +1,58 @@
+package golden_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sseServer(t *testing.T, keepOpen bool) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(": keep-alive\n\nid: 1\nevent: update\ndata: {\"progress\": 50}\n\nretry: 1000\ndata: first\ndata: second\n\n"))
+		w.(http.Flusher).Flush()
+		if keepOpen {
+			<-r.Context().Done()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRequestSSE(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, sseServer(t, false).URL, nil)
+	require.NoError(t, err)
+	events, ok := golden.RequestSSE(t, http.DefaultClient, req, http.StatusOK, golden.SSEOptions{})
+	assert.True(t, ok)
+	assert.Equal(t, []golden.SSEEvent{
+		{ID: "1", Event: "update", Data: `{"progress": 50}`},
+		{Retry: "1000", Data: "first\nsecond"},
+	}, events)
+}
+
+func TestRequestSSE_Limits(t *testing.T) {
+	srv := sseServer(t, true)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	mt := &mockT{name: "TestRequestSSE"}
+	events, _ := golden.RequestSSE(mt, http.DefaultClient, req, http.StatusOK, golden.SSEOptions{MaxEvents: 1})
+	assert.Len(t, events, 1)
+
+	req, err = http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	mt = &mockT{name: "TestRequestSSE"}
+	events, ok := golden.RequestSSE(mt, http.DefaultClient, req, http.StatusOK, golden.SSEOptions{Timeout: 100 * time.Millisecond})
+	assert.True(t, ok)
+	assert.Len(t, events, 2)
+}
+
+func TestSSETranscript(t *testing.T) {
+	events := []golden.SSEEvent{{Event: "ping"}, {ID: "2", Data: "a\nb"}}
+	assert.Equal(t, "--- event 1\nevent: ping\n--- event 2\nid: 2\ndata: a\ndata: b\n", golden.SSETranscript(events))
+}
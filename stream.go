@@ -0,0 +1,103 @@
+package golden
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StreamOptions configures how RequestStream reads and renders the streamed response.
+type StreamOptions struct {
+	// MaxChunks stops reading after the given number of chunks, the stream is read until it's closed when zero.
+	MaxChunks int
+	// Timeout stops reading after the given duration, the chunks received so far are asserted.
+	Timeout time.Duration
+	// TimeBucket renders the time of each chunk relative to the first one as a placeholder with the number of elapsed buckets,
+	// e.g. <<T+2>> for a chunk received 2 seconds after the first one with one second buckets. Timing isn't rendered when zero.
+	// Choose a bucket much larger than the expected jitter, so that the timing is stable between the runs.
+	TimeBucket time.Duration
+}
+
+// StreamChunk is a piece of the streamed response body as returned by a single read.
+type StreamChunk struct {
+	Data string
+	// Elapsed is the time since the first chunk was received.
+	Elapsed time.Duration
+}
+
+// RequestStream sends the request and reads the response body chunk by chunk until the stream is closed or the limits
+// in opts are hit. It asserts that the response status code is equal to the expectedStatusCode and that the chunks
+// rendered by StreamTranscript are equal to the golden file content, so the chunking behavior of streaming endpoints
+// is asserted instead of only the concatenated body.
+// The chunks are the data returned by the reads of the body, HTTP/1.1 chunks which arrive together may be merged.
+func RequestStream(t T, client Client, req *http.Request, expectedStatusCode int, opts StreamOptions) ([]StreamChunk, bool) {
+	return Default().RequestStream(t, client, req, expectedStatusCode, opts)
+}
+
+func (h *FileHandler) RequestStream(t T, client Client, req *http.Request, expectedStatusCode int, opts StreamOptions) ([]StreamChunk, bool) {
+	t.Helper()
+	if opts.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), opts.Timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := h.Retry.do(t, client, req)
+	NoError(t, err, "client.Do failed")
+	defer resp.Body.Close()
+
+	if h.RequestRecorder != nil {
+		h.RequestRecorder.Record(RequestRecord{Test: t.Name(), Method: req.Method, Path: req.URL.Path, StatusCode: resp.StatusCode})
+	}
+
+	ok := true
+	if resp.StatusCode != expectedStatusCode {
+		ok = false
+		t.Errorf("expected status code %d, got %d", expectedStatusCode, resp.StatusCode)
+	}
+
+	var chunks []StreamChunk
+	var first time.Time
+	buf := make([]byte, 32*1024)
+	for opts.MaxChunks <= 0 || len(chunks) < opts.MaxChunks {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			now := time.Now()
+			if first.IsZero() {
+				first = now
+			}
+			chunks = append(chunks, StreamChunk{Data: string(buf[:n]), Elapsed: now.Sub(first)})
+		}
+		// Read errors are expected when the timeout cancels the request.
+		if err != nil {
+			break
+		}
+	}
+
+	style := h.Placeholder
+	if style == nil {
+		style = AngleBrackets
+	}
+	return chunks, h.Assert(t, StreamTranscript(chunks, opts.TimeBucket, style)) && ok
+}
+
+// StreamTranscript renders the chunks in order, each preceded by a header line with its sequence number and,
+// when bucket isn't zero, the relative time placeholder rendered with the style, see StreamOptions.TimeBucket.
+// A newline is added after the chunks which don't end with one.
+func StreamTranscript(chunks []StreamChunk, bucket time.Duration, style PlaceholderStyle) string {
+	sb := &strings.Builder{}
+	for i, c := range chunks {
+		fmt.Fprintf(sb, "--- chunk %d", i+1)
+		if bucket > 0 {
+			sb.WriteString(" " + style("T+"+strconv.Itoa(int(c.Elapsed/bucket))))
+		}
+		sb.WriteString("\n" + c.Data)
+		if !strings.HasSuffix(c.Data, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package golden_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i, chunk := range []string{`{"n":1}` + "\n", `{"n":2}`} {
+			if i > 0 {
+				time.Sleep(300 * time.Millisecond)
+			}
+			_, _ = w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+		}
+		if r.URL.Query().Has("open") {
+			<-r.Context().Done()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Chdir(t.TempDir())
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	chunks, ok := fh.RequestStream(&mockT{name: "TestStream"}, http.DefaultClient, req, http.StatusOK, golden.StreamOptions{TimeBucket: 200 * time.Millisecond})
+	assert.True(t, ok)
+	require.Len(t, chunks, 2)
+	assert.Zero(t, chunks[0].Elapsed)
+
+	b, err := os.ReadFile("testdata/TestStream/TestStream.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "--- chunk 1 <<T+0>>\n{\"n\":1}\n--- chunk 2 <<T+1>>\n{\"n\":2}\n", string(b))
+
+	req, err = http.NewRequest(http.MethodGet, srv.URL+"?open", nil)
+	require.NoError(t, err)
+	chunks, ok = fh.RequestStream(&mockT{name: "TestStream"}, http.DefaultClient, req, http.StatusOK, golden.StreamOptions{MaxChunks: 1, Timeout: 5 * time.Second})
+	assert.True(t, ok)
+	assert.Len(t, chunks, 1)
+	b, err = os.ReadFile("testdata/TestStream/TestStream.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "--- chunk 1\n{\"n\":1}\n", string(b))
+}
@@ -0,0 +1,52 @@
+package golden
+
+import (
+	"net/http"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Suite can be embedded into testify suites instead of suite.Suite, it wires the suite's current T into the handler
+// so the golden file assertions don't need the T to be passed manually:
+//
+//	type APISuite struct {
+//		golden.Suite
+//	}
+//
+//	func (s *APISuite) TestUsers() {
+//		s.Golden(listUsers())
+//	}
+//
+//	func TestAPISuite(t *testing.T) {
+//		suite.Run(t, new(APISuite))
+//	}
+type Suite struct {
+	suite.Suite
+	// Handler is used for the assertions, the default handler is used when nil, see Default.
+	Handler *FileHandler
+}
+
+// Golden checks the golden file content against the given data, see Assert.
+func (s *Suite) Golden(data string) bool {
+	s.T().Helper()
+	return s.handler().Assert(s.T(), data)
+}
+
+// GoldenError checks the golden file content against the error, see AssertError.
+func (s *Suite) GoldenError(err error) bool {
+	s.T().Helper()
+	return s.handler().AssertError(s.T(), err)
+}
+
+// GoldenRequest sends the request and checks the response against the golden file, see Request.
+func (s *Suite) GoldenRequest(client Client, req *http.Request, expectedStatusCode int) (*http.Response, bool) {
+	s.T().Helper()
+	return s.handler().Request(s.T(), client, req, expectedStatusCode)
+}
+
+func (s *Suite) handler() *FileHandler {
+	if s.Handler != nil {
+		return s.Handler
+	}
+	return Default()
+}
@@ -0,0 +1,25 @@
+package golden_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/suite"
+)
+
+type goldenSuite struct {
+	golden.Suite
+}
+
+func (s *goldenSuite) TestGolden() {
+	s.True(s.Golden("suite data"))
+}
+
+func (s *goldenSuite) TestGoldenError() {
+	s.True(s.GoldenError(errors.New("suite error")))
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(goldenSuite))
+}
@@ -0,0 +1,128 @@
+package golden
+
+import (
+	"encoding/xml"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SVGOptions configures the SVG normalization done by SVGProcessor.
+type SVGOptions struct {
+	// Precision is the number of decimals numbers are rounded to, defaults to 3.
+	Precision int
+	// KeepComments keeps the XML comments which are removed by default.
+	KeepComments bool
+}
+
+// svgEditorPrefixes are the namespace prefixes of the elements and attributes added by the SVG editors.
+var svgEditorPrefixes = map[string]bool{"inkscape": true, "sodipodi": true, "sketch": true, "serif": true}
+
+// svgGeometryAttrs are the attributes whose numbers are rounded. Other attributes like id, class and data-*
+// are kept as is even when their values are numbers.
+var svgGeometryAttrs = map[string]bool{
+	"d": true, "points": true, "transform": true, "viewBox": true,
+	"x": true, "y": true, "x1": true, "y1": true, "x2": true, "y2": true, "dx": true, "dy": true,
+	"cx": true, "cy": true, "r": true, "rx": true, "ry": true, "fx": true, "fy": true,
+	"width": true, "height": true, "offset": true, "rotate": true,
+	"stroke-width": true, "stroke-dasharray": true, "stroke-dashoffset": true, "font-size": true,
+	"opacity": true, "fill-opacity": true, "stroke-opacity": true, "stop-opacity": true,
+}
+
+var svgNumber = regexp.MustCompile(`[-+]?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?`)
+
+// PrettySVG normalizes the SVG using the default SVGOptions, see SVGProcessor.
+func PrettySVG(t T, data string) string {
+	return SVGProcessor(SVGOptions{})(t, data)
+}
+
+// SVGProcessor returns ProcessContent function which normalizes the SVG generated for example by chart libraries:
+// attributes are sorted by name, numbers in path data, points, transforms and geometry attributes are rounded
+// to the configured precision and the editor metadata like <metadata> elements and Inkscape and Sodipodi
+// elements and attributes are removed. The result is formatted like PrettyXML.
+func SVGProcessor(opts SVGOptions) func(T, string) string {
+	precision := opts.Precision
+	if precision <= 0 {
+		precision = 3
+	}
+
+	return func(t T, data string) string {
+		tokens, err := readXMLTokens(data)
+		if err != nil {
+			NoError(t, err, "failed to parse SVG")
+			return data
+		}
+
+		var out []xml.Token
+		skipDepth := 0
+		for _, tok := range tokens {
+			if skipDepth > 0 {
+				switch tok.(type) {
+				case xml.StartElement:
+					skipDepth++
+				case xml.EndElement:
+					skipDepth--
+				}
+				continue
+			}
+
+			switch tok := tok.(type) {
+			case xml.StartElement:
+				if tok.Name.Local == "metadata" || svgEditorPrefixes[tok.Name.Space] {
+					skipDepth = 1
+					continue
+				}
+				out = append(out, normalizeSVGElement(tok, precision))
+			case xml.Comment:
+				if opts.KeepComments {
+					out = append(out, tok)
+				}
+			default:
+				out = append(out, tok)
+			}
+		}
+		return writeXMLTokens(out)
+	}
+}
+
+func normalizeSVGElement(el xml.StartElement, precision int) xml.StartElement {
+	attrs := make([]xml.Attr, 0, len(el.Attr))
+	for _, attr := range el.Attr {
+		if svgEditorPrefixes[attr.Name.Space] || (attr.Name.Space == "xmlns" && svgEditorPrefixes[attr.Name.Local]) {
+			continue
+		}
+
+		if attr.Name.Space == "" && svgGeometryAttrs[attr.Name.Local] {
+			attr.Value = roundSVGNumbers(attr.Value, precision)
+		}
+		attrs = append(attrs, attr)
+	}
+
+	sort.SliceStable(attrs, func(i, j int) bool {
+		return xmlName(attrs[i].Name) < xmlName(attrs[j].Name)
+	})
+	el.Attr = attrs
+	return el
+}
+
+// roundSVGNumbers rounds all the numbers in the value. Numbers written next to each other without a separator,
+// e.g. "1.5.5" or "30-0.1" in path data, are separated with a space since rounding could otherwise merge them.
+func roundSVGNumbers(value string, precision int) string {
+	sb := &strings.Builder{}
+	last := 0
+	for i, loc := range svgNumber.FindAllStringIndex(value, -1) {
+		sb.WriteString(value[last:loc[0]])
+		if i > 0 && loc[0] == last {
+			sb.WriteString(" ")
+		}
+		num := value[loc[0]:loc[1]]
+		if f, err := strconv.ParseFloat(num, 64); err == nil {
+			num = roundFloat(f, precision, false)
+		}
+		sb.WriteString(num)
+		last = loc[1]
+	}
+	sb.WriteString(value[last:])
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+const inkscapeSVG = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Created with Inkscape -->
+<svg xmlns:inkscape="http://www.inkscape.org/namespaces/inkscape" xmlns:sodipodi="http://sodipodi.sourceforge.net/DTD/sodipodi-0.dtd"
+     width="100.00001" height="50" xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100.00001 50" inkscape:version="1.3">
+  <sodipodi:namedview id="namedview1" pagecolor="#ffffff"><inkscape:page x="0" y="0"/></sodipodi:namedview>
+  <metadata><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"/></metadata>
+  <path stroke="black" inkscape:label="line" d="M10.123456,20.987654L30-0.0001 40.5.25Z" fill="none"/>
+  <polyline points="1.00049,2 3.14159,4"/>
+  <text y="10.5" x="5">Total 1.23456</text>
+</svg>`
+
+func TestPrettySVG(t *testing.T) {
+	golden.Assert(t, golden.PrettySVG(t, inkscapeSVG))
+}
+
+func TestSVGProcessor(t *testing.T) {
+	mt := &mockT{name: "TestSVGProcessor"}
+	got := golden.SVGProcessor(golden.SVGOptions{Precision: 1, KeepComments: true})(mt, inkscapeSVG)
+	assert.Contains(t, got, `<path d="M10.1,21L30 0 40.5 0.3Z" fill="none" stroke="black"></path>`)
+	assert.Contains(t, got, "<!-- Created with Inkscape -->")
+	assert.Contains(t, got, "<text x=\"5\" y=\"10.5\">Total 1.23456</text>")
+	assert.NotContains(t, got, "inkscape:")
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestSVGProcessor"}
+	golden.PrettySVG(mt, "<svg>")
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "failed to parse SVG")
+}
+
+func TestSVGProcessor_NonGeometryAttrs(t *testing.T) {
+	mt := &mockT{name: "TestSVGProcessor"}
+	got := golden.SVGProcessor(golden.SVGOptions{Precision: 1})(mt, `<svg><rect id="1.2345" class="2.5" data-value="0.123456" width="10.25"/></svg>`)
+	assert.Contains(t, got, `<rect class="2.5" data-value="0.123456" id="1.2345" width="10.3"></rect>`)
+	assert.False(t, mt.failed)
+}
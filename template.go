@@ -0,0 +1,114 @@
+package golden
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Template is implemented by both text/template.Template and html/template.Template.
+type Template interface {
+	Execute(w io.Writer, data any) error
+}
+
+// AssertTemplate executes the template with the given data and checks the golden file content against the output.
+// Template execution errors are reported using NoError.
+func AssertTemplate(t T, tmpl Template, data any) bool {
+	return Default().AssertTemplate(t, tmpl, data)
+}
+
+func (h *FileHandler) AssertTemplate(t T, tmpl Template, data any) bool {
+	t.Helper()
+	sb := &strings.Builder{}
+	if err := tmpl.Execute(sb, data); err != nil {
+		NoError(t, err, "failed to execute template")
+		return false
+	}
+	return h.Assert(t, sb.String())
+}
+
+// WithTemplateData returns a copy of the handler which renders the golden files as text/template with the values
+// before the comparison, so that the environment dependent values like ports and temporary directories stay in the golden files:
+//
+//	fh := golden.DefaultHandler.WithTemplateData(map[string]any{"Port": port, "TmpDir": t.TempDir()})
+//	fh.Assert(t, output) // golden file: listening on 127.0.0.1:{{ .Port }}
+//
+// When recreating, the occurrences of the values in the actual data are replaced with the template actions,
+// longer values first. Only whole tokens are replaced, e.g. Port 80 isn't replaced in 1800.
+// Golden files containing template delimiters as content can't be used with the template data.
+func (h *FileHandler) WithTemplateData(data map[string]any) *FileHandler {
+	c := *h
+	c.TemplateData = data
+	return &c
+}
+
+// renderGolden renders the golden file content with the template data.
+func renderGolden(name, content string, data map[string]any) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", err
+	}
+
+	sb := &strings.Builder{}
+	if err := tmpl.Execute(sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// templatize replaces the whole token occurrences of the template data values with the template actions referring to them.
+func templatize(content string, data map[string]any) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		if fmt.Sprint(data[k]) != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		vi, vj := fmt.Sprint(data[keys[i]]), fmt.Sprint(data[keys[j]])
+		if len(vi) != len(vj) {
+			return len(vi) > len(vj)
+		}
+		return keys[i] < keys[j]
+	})
+
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = fmt.Sprint(data[k])
+	}
+
+	sb := &strings.Builder{}
+	for i := 0; i < len(content); {
+		replaced := false
+		for j, v := range values {
+			if strings.HasPrefix(content[i:], v) && wholeToken(content, i, i+len(v)) {
+				sb.WriteString("{{ ." + keys[j] + " }}")
+				i += len(v)
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			sb.WriteByte(content[i])
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// wholeToken reports whether content[start:end] doesn't continue a word or a number on either side.
+func wholeToken(content string, start, end int) bool {
+	first, _ := utf8.DecodeRuneInString(content[start:end])
+	last, _ := utf8.DecodeLastRuneInString(content[start:end])
+	before, _ := utf8.DecodeLastRuneInString(content[:start])
+	after, _ := utf8.DecodeRuneInString(content[end:])
+	return !(start > 0 && isWordRune(first) && isWordRune(before)) && !(end < len(content) && isWordRune(last) && isWordRune(after))
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
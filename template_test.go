@@ -0,0 +1,73 @@
+package golden_test
+
+import (
+	htmltemplate "html/template"
+	"os"
+	"testing"
+	"text/template"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertTemplate(t *testing.T) {
+	data := map[string]any{"Name": "<someone>", "Items": []string{"a", "b"}}
+
+	t.Run("text", func(t *testing.T) {
+		tmpl := template.Must(template.New("").Parse("Hello {{ .Name }}!\n{{ range .Items }}- {{ . }}\n{{ end }}"))
+		golden.AssertTemplate(t, tmpl, data)
+	})
+
+	t.Run("html", func(t *testing.T) {
+		tmpl := htmltemplate.Must(htmltemplate.New("").Parse("<p>Hello {{ .Name }}!</p>\n<ul>{{ range .Items }}<li>{{ . }}</li>{{ end }}</ul>\n"))
+		golden.AssertTemplate(t, tmpl, data)
+	})
+}
+
+func TestAssertTemplate_ExecError(t *testing.T) {
+	tmpl := template.Must(template.New("").Option("missingkey=error").Parse("{{ .Missing }}"))
+	mt := &mockT{name: "TestTemplateError"}
+	assert.False(t, golden.AssertTemplate(mt, tmpl, map[string]any{}))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "failed to execute template")
+	assert.NoDirExists(t, "./testdata/TestTemplateError")
+}
+
+func TestWithTemplateData(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}).WithTemplateData(map[string]any{"Port": 8080, "TmpDir": "/tmp/run1", "Empty": ""})
+
+	assert.True(t, fh.Assert(&mockT{name: "TestTemplateData"}, "listening on 127.0.0.1:8080\nwriting to /tmp/run1/out\n"))
+	b, err := os.ReadFile("testdata/TestTemplateData/TestTemplateData.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "listening on 127.0.0.1:{{ .Port }}\nwriting to {{ .TmpDir }}/out\n", string(b))
+
+	fh = fh.WithTemplateData(map[string]any{"Port": 9090, "TmpDir": "/tmp/run2"})
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt := &mockT{name: "TestTemplateData"}
+	assert.True(t, fh.Assert(mt, "listening on 127.0.0.1:9090\nwriting to /tmp/run2/out\n"))
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestTemplateData"}
+	assert.False(t, fh.WithTemplateData(map[string]any{"Port": 1}).Assert(mt, "data"))
+	assert.Contains(t, mt.msg, "failed to render golden file template")
+}
+
+func TestWithTemplateData_WholeTokens(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := (&golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+	}).WithTemplateData(map[string]any{"Port": 80, "User": "bob"})
+
+	assert.True(t, fh.Assert(&mockT{name: "TestTemplateData"}, "GET :80/users/bob took 1800ms, bobby 80x\n"))
+	b, err := os.ReadFile("testdata/TestTemplateData/TestTemplateData.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "GET :{{ .Port }}/users/{{ .User }} took 1800ms, bobby 80x\n", string(b))
+}
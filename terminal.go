@@ -0,0 +1,43 @@
+package golden
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ansiEscape matches CSI sequences like colors and cursor movement, OSC sequences like hyperlinks and the other escapes.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes the ANSI escape sequences, e.g. colors, cursor movement and hyperlinks, from the terminal output,
+// so that the golden files don't depend on the color settings. Lines rewritten with carriage return,
+// like progress bars, are reduced to their final state.
+func StripANSI(_ T, data string) string {
+	data = ansiEscape.ReplaceAllString(data, "")
+	lines := strings.Split(data, "\n")
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if j := strings.LastIndex(line, "\r"); j >= 0 {
+			line = line[j+1:]
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NormalizeTerminalWidth makes the terminal dependent output deterministic for the duration of the test:
+// COLUMNS is set to the width, and NO_COLOR and TERM=dumb disable the colors of the libraries honoring them.
+// T must implement Setenv like *testing.T does.
+func NormalizeTerminalWidth(t T, width int) {
+	t.Helper()
+	s, ok := t.(interface{ Setenv(key, value string) })
+	if !ok {
+		t.Errorf("NormalizeTerminalWidth requires T implementing Setenv, got %T", t)
+		t.FailNow()
+		return
+	}
+
+	s.Setenv("COLUMNS", strconv.Itoa(width))
+	s.Setenv("NO_COLOR", "1")
+	s.Setenv("TERM", "dumb")
+}
@@ -0,0 +1,26 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripANSI(t *testing.T) {
+	data := "\x1b[1;32mok\x1b[0m \x1b]8;;https://example.com\x07link\x1b]8;;\x07\n" +
+		"progress 10%\rprogress 50%\rprogress 100%\r\n" +
+		"\x1b[2K\x1b[1Gdone\n"
+	assert.Equal(t, "ok link\nprogress 100%\ndone\n", golden.StripANSI(t, data))
+}
+
+func TestNormalizeTerminalWidth(t *testing.T) {
+	golden.NormalizeTerminalWidth(t, 80)
+	assert.Equal(t, "80", os.Getenv("COLUMNS"))
+	assert.Equal(t, "1", os.Getenv("NO_COLOR"))
+
+	mt := &mockT{}
+	golden.NormalizeTerminalWidth(mt, 80)
+	assert.True(t, mt.failed)
+}
@@ -0,0 +1,28 @@
+package inline
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+)
+
+func TestSnapshot(t *testing.T) {
+	first := "old"
+	second := `old`
+	golden.AssertInline(t, "new\nvalue", &first)
+	golden.AssertInline(t, "with `backquote`", &second)
+	if first != "new\nvalue" {
+		t.Errorf("want isn't updated: %q", first)
+	}
+}
+
+func TestMissingLiteral(t *testing.T) {
+	var missing string
+	golden.AssertInline(t, "data", &missing)
+}
+
+func TestVerifying(t *testing.T) {
+	t.Setenv("GOLDEN_VERIFY_OUTPUT_DIR", t.TempDir())
+	want := "old"
+	golden.AssertInline(t, "new", &want)
+}
@@ -0,0 +1,70 @@
+package golden
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// UnicodeForm is the Unicode normalization form applied by EqualText.
+type UnicodeForm int
+
+const (
+	// NoNormalization compares the code points as they are.
+	NoNormalization UnicodeForm = iota
+	// NFC composes the characters, e.g. "e" followed by combining acute accent becomes "é".
+	NFC
+	// NFD decomposes the characters, e.g. "é" becomes "e" followed by combining acute accent.
+	NFD
+	// NFKC composes the characters after replacing the compatibility characters, e.g. the "ﬁ" ligature becomes "fi".
+	NFKC
+	// NFKD decomposes the characters after replacing the compatibility characters.
+	NFKD
+)
+
+// TextOptions configures EqualText.
+type TextOptions struct {
+	// Normalization is the Unicode normalization form applied to both sides.
+	Normalization UnicodeForm
+	// FoldCase compares the text case-insensitively using Unicode case folding.
+	FoldCase bool
+	// CollapseWhitespace replaces the runs of whitespace within the lines with a single space and trims the lines,
+	// including the non-breaking spaces used by locale-aware number and date formatting. Line breaks are kept.
+	CollapseWhitespace bool
+}
+
+// EqualText returns Equal function which normalizes both the golden file and the actual content with the options
+// before comparing them with EqualWithDiff, for asserting text produced by locale-aware libraries
+// where byte-identical output isn't achievable across environments. The diff is rendered from the normalized text.
+func EqualText(opts TextOptions) func(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+	return func(t T, expected, actual string, msgAndArgs ...interface{}) bool {
+		t.Helper()
+		return EqualWithDiff(t, opts.normalize(expected), opts.normalize(actual), msgAndArgs...)
+	}
+}
+
+func (o TextOptions) normalize(s string) string {
+	switch o.Normalization {
+	case NFC:
+		s = norm.NFC.String(s)
+	case NFD:
+		s = norm.NFD.String(s)
+	case NFKC:
+		s = norm.NFKC.String(s)
+	case NFKD:
+		s = norm.NFKD.String(s)
+	}
+	if o.FoldCase {
+		s = cases.Fold().String(s)
+	}
+	if o.CollapseWhitespace {
+		lines := strings.Split(s, "\n")
+		for i, line := range lines {
+			lines[i] = strings.Join(strings.FieldsFunc(line, unicode.IsSpace), " ")
+		}
+		s = strings.Join(lines, "\n")
+	}
+	return s
+}
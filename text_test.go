@@ -0,0 +1,36 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqualText(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		opts             golden.TextOptions
+		expected, actual string
+		equal            bool
+	}{
+		{name: "composed", opts: golden.TextOptions{Normalization: golden.NFC}, expected: "Café", actual: "Cafe\u0301", equal: true},
+		{name: "decomposed", opts: golden.TextOptions{Normalization: golden.NFD}, expected: "Café", actual: "Cafe\u0301", equal: true},
+		{name: "compatibility", opts: golden.TextOptions{Normalization: golden.NFKC}, expected: "ﬁle", actual: "file", equal: true},
+		{name: "not normalized", opts: golden.TextOptions{}, expected: "Café", actual: "Cafe\u0301"},
+		{name: "fold case", opts: golden.TextOptions{FoldCase: true}, expected: "STRASSE Ärger", actual: "strasse ärger", equal: true},
+		{name: "case differs", opts: golden.TextOptions{}, expected: "Total", actual: "total"},
+		{
+			name:     "whitespace",
+			opts:     golden.TextOptions{CollapseWhitespace: true},
+			expected: "1\u00a0234,50\u202f€\nline  two \n",
+			actual:   "1 234,50 €\n  line two\n",
+			equal:    true,
+		},
+		{name: "line breaks are kept", opts: golden.TextOptions{CollapseWhitespace: true}, expected: "a\nb", actual: "a b"},
+	} {
+		mt := &mockT{name: tc.name}
+		assert.Equal(t, tc.equal, golden.EqualText(tc.opts)(mt, tc.expected, tc.actual), tc.name)
+		assert.Equal(t, !tc.equal, mt.failed, tc.name)
+	}
+}
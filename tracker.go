@@ -0,0 +1,166 @@
+package golden
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUntrackedGolden is returned by Tracker when strict mode prevents creating a golden file for an unknown test.
+var ErrUntrackedGolden = errors.New("golden file would be created for a test which was not seen in the previous tracked run")
+
+// ErrTooManyNewGoldens is returned by Tracker when more than MaxNew golden files would be created during the run.
+var ErrTooManyNewGoldens = errors.New("too many new golden files created during the run")
+
+// Tracker records which tests asserted which golden files during the run and stores them into a manifest file.
+// The manifest of the previous run is used for detecting golden files which are created for unknown test names,
+// e.g. because of a typo in t.Run name, which would silently create new golden files and orphan the old ones.
+// Tracker is safe for concurrent use and it's intended to be set up in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		tracker, err := golden.NewTracker("testdata/golden.tracked")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		tracker.Strict = true
+//		golden.DefaultHandler.Tracker = tracker
+//
+//		code := m.Run()
+//		if err := tracker.Save(); err != nil {
+//			log.Fatal(err)
+//		}
+//		os.Exit(code)
+//	}
+type Tracker struct {
+	// Path of the manifest file.
+	Path string
+	// Strict makes recreating fail when a new golden file would be created for a test name
+	// that wasn't seen in the previous tracked run. Strict mode is disabled when there's no previous manifest.
+	Strict bool
+	// MaxNew limits the number of new golden files that can be created during the run, zero means no limit.
+	MaxNew int
+	// Storage is used by Save for checking that the golden files of the previous run still exist,
+	// the OS filesystem is used when nil.
+	Storage Storage
+
+	mu       sync.Mutex
+	previous map[string]string
+	seen     map[string]string
+	created  int
+}
+
+// NewTracker creates a new Tracker and loads the manifest of the previous run from the given path if it exists.
+func NewTracker(path string) (*Tracker, error) {
+	previous, err := ReadManifest(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return &Tracker{
+		Path:     path,
+		previous: previous,
+		seen:     map[string]string{},
+	}, nil
+}
+
+// Track records that the test asserted the golden file.
+// When created is true, i.e. the golden file doesn't exist yet and the assertion creates it, strict mode
+// and MaxNew limits are checked. FileHandler checks the existence with its Storage.
+func (tr *Tracker) Track(testName, fileName string, created bool) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if !created {
+		tr.seen[testName] = filepath.ToSlash(fileName)
+		return nil
+	}
+
+	if _, ok := tr.previous[testName]; tr.Strict && !ok && len(tr.previous) > 0 {
+		return fmt.Errorf("%w: test %q, golden file %q; fix the test name or disable strict mode to accept the new test", ErrUntrackedGolden, testName, fileName)
+	}
+
+	tr.created++
+	if tr.MaxNew > 0 && tr.created > tr.MaxNew {
+		return fmt.Errorf("%w: limit is %d, test %q, golden file %q", ErrTooManyNewGoldens, tr.MaxNew, testName, fileName)
+	}
+
+	tr.seen[testName] = filepath.ToSlash(fileName)
+	return nil
+}
+
+// Save writes the manifest file containing the tests seen during the run.
+// Entries of the previous run are kept as long as their golden files exist, so running a subset of the tests
+// doesn't drop the other tests from the manifest.
+func (tr *Tracker) Save() error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	entries := make(map[string]string, len(tr.previous)+len(tr.seen))
+	for name, fileName := range tr.previous {
+		if fileExists(tr.storage(), filepath.FromSlash(fileName)) {
+			entries[name] = fileName
+		}
+	}
+	for name, fileName := range tr.seen {
+		entries[name] = fileName
+	}
+
+	return WriteManifest(tr.Path, entries)
+}
+
+func (tr *Tracker) storage() Storage {
+	if tr.Storage == nil {
+		return OSStorage{}
+	}
+	return tr.Storage
+}
+
+// ReadManifest reads the manifest file written by Tracker, it maps test names to golden file paths.
+func ReadManifest(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := map[string]string{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" {
+			continue
+		}
+
+		name, fileName, ok := strings.Cut(line, "\t")
+		if !ok {
+			return nil, fmt.Errorf("invalid manifest line: %q", line)
+		}
+		entries[name] = fileName
+	}
+	return entries, s.Err()
+}
+
+// WriteManifest writes the manifest file mapping test names to golden file paths.
+// Each line contains the test name and the golden file path separated by tab, lines are sorted by test name.
+func WriteManifest(path string, entries map[string]string) error {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb := &strings.Builder{}
+	for _, name := range names {
+		fmt.Fprintf(sb, "%s\t%s\n", name, entries[name])
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0o600)
+}
@@ -0,0 +1,104 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	t.Chdir(t.TempDir())
+	manifest := filepath.Join("testdata", "golden.tracked")
+
+	newHandler := func(tr *golden.Tracker) *golden.FileHandler {
+		return &golden.FileHandler{
+			FileName:       golden.TestNameToFilePath,
+			ShouldRecreate: func(golden.T) bool { return true },
+			Equal:          golden.EqualWithDiff,
+			Tracker:        tr,
+		}
+	}
+
+	tr, err := golden.NewTracker(manifest)
+	require.NoError(t, err)
+	tr.Strict = true
+
+	fh := newHandler(tr)
+	for _, name := range []string{"TestFunc/a", "TestFunc/b"} {
+		mt := &mockT{name: name}
+		assert.True(t, fh.Assert(mt, "data"), "strict mode is disabled without previous manifest")
+		assert.False(t, mt.failed)
+	}
+	require.NoError(t, tr.Save())
+
+	entries, err := golden.ReadManifest(manifest)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"TestFunc/a": "testdata/TestFunc/a.golden",
+		"TestFunc/b": "testdata/TestFunc/b.golden",
+	}, entries)
+
+	tr, err = golden.NewTracker(manifest)
+	require.NoError(t, err)
+	tr.Strict = true
+	fh = newHandler(tr)
+
+	mt := &mockT{name: "TestFunc/a"}
+	assert.True(t, fh.Assert(mt, "new data"))
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestFunc/typo"}
+	assert.False(t, fh.Assert(mt, "data"))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, golden.ErrUntrackedGolden.Error())
+	assert.NoFileExists(t, "testdata/TestFunc/typo.golden")
+
+	require.NoError(t, os.Remove("testdata/TestFunc/b.golden"))
+	require.NoError(t, tr.Save())
+	entries, err = golden.ReadManifest(manifest)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"TestFunc/a": "testdata/TestFunc/a.golden"}, entries, "orphaned entries are dropped")
+}
+
+func TestTracker_MaxNew(t *testing.T) {
+	t.Chdir(t.TempDir())
+	tr, err := golden.NewTracker("golden.tracked")
+	require.NoError(t, err)
+	tr.MaxNew = 1
+
+	require.NoError(t, tr.Track("TestFunc/a", "testdata/TestFunc/a.golden", true))
+	require.ErrorIs(t, tr.Track("TestFunc/b", "testdata/TestFunc/b.golden", true), golden.ErrTooManyNewGoldens)
+	require.NoError(t, tr.Track("TestFunc/c", "testdata/TestFunc/c.golden", false))
+}
+
+func TestTracker_Storage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	storage := &memStorage{files: map[string]string{"testdata/TestFunc/a.golden": "data"}}
+	tr, err := golden.NewTracker("golden.tracked")
+	require.NoError(t, err)
+	tr.MaxNew = 1
+	tr.Storage = storage
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		Storage:        storage,
+		Tracker:        tr,
+	}
+	assert.True(t, fh.Assert(&mockT{name: "TestFunc/a"}, "data"), "existing golden file in the storage isn't counted as new")
+	assert.True(t, fh.Assert(&mockT{name: "TestFunc/b"}, "data"))
+	require.NoError(t, tr.Save())
+
+	tr, err = golden.NewTracker("golden.tracked")
+	require.NoError(t, err)
+	tr.Storage = storage
+	require.NoError(t, tr.Save())
+	entries, err := golden.ReadManifest("golden.tracked")
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "entries of the golden files existing in the storage are kept")
+}
@@ -0,0 +1,46 @@
+package golden
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// BOMPolicy selects how the UTF-8 byte order mark is handled, see FileHandler.BOM.
+type BOMPolicy int
+
+const (
+	// BOMKeep compares and writes the content as is.
+	BOMKeep BOMPolicy = iota
+	// BOMStrip removes the byte order mark from the actual content and the golden file before the comparison,
+	// the golden files are written without it.
+	BOMStrip
+	// BOMAdd compares the content without the byte order mark like BOMStrip, but writes the golden files with it,
+	// e.g. for fixtures consumed by tools which require it.
+	BOMAdd
+)
+
+// BOM is the UTF-8 encoded byte order mark.
+const BOM = "\uFEFF"
+
+// stripBOM removes the byte order mark unless the policy keeps it.
+func (p BOMPolicy) stripBOM(s string) string {
+	if p == BOMKeep {
+		return s
+	}
+	return strings.TrimPrefix(s, BOM)
+}
+
+// checkUTF8 fails the test when the content isn't valid UTF-8, what describes the content in the failure message.
+func checkUTF8(t T, what, s string) bool {
+	t.Helper()
+	for i, r := range s {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(s[i:]); size == 1 {
+				t.Errorf("%s isn't valid UTF-8: invalid byte 0x%02x at offset %d", what, s[i], i)
+				t.FailNow()
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUTF8(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+		ValidateUTF8:   true,
+	}
+
+	mt := &mockT{name: "TestUTF8"}
+	assert.False(t, fh.Assert(mt, "héllo\xffworld"))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "actual content isn't valid UTF-8: invalid byte 0xff at offset 6")
+
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestUTF8/TestUTF8.golden", []byte("ok\x80")))
+	mt = &mockT{name: "TestUTF8"}
+	assert.False(t, fh.Assert(mt, "ok"))
+	assert.Contains(t, mt.msg, "golden file testdata/TestUTF8/TestUTF8.golden isn't valid UTF-8: invalid byte 0x80 at offset 2")
+
+	mt = &mockT{name: "TestUTF8"}
+	assert.False(t, fh.Assert(mt, "valid � replacement character"))
+	assert.NotContains(t, mt.msg, "actual content isn't valid UTF-8")
+}
+
+func TestBOM(t *testing.T) {
+	t.Chdir(t.TempDir())
+	name := "testdata/TestBOM/TestBOM.golden"
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		BOM:            golden.BOMAdd,
+	}
+
+	mt := &mockT{name: "TestBOM"}
+	assert.True(t, fh.Assert(mt, golden.BOM+"data"))
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, golden.BOM+"data", string(b))
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	assert.True(t, fh.Assert(mt, "data"))
+
+	fh.BOM = golden.BOMStrip
+	assert.True(t, fh.Assert(mt, golden.BOM+"data"))
+	assert.False(t, mt.failed)
+
+	fh.ShouldRecreate = func(golden.T) bool { return true }
+	assert.True(t, fh.Assert(mt, golden.BOM+"data"))
+	b, err = os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(b))
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	fh.BOM = golden.BOMKeep
+	mt = &mockT{name: "TestBOM"}
+	assert.False(t, fh.Assert(mt, golden.BOM+"data"))
+}
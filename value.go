@@ -0,0 +1,86 @@
+package golden
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/google/go-cmp/cmp"
+)
+
+// AssertValue stores the value as indented JSON golden file and checks it against the golden file content.
+// By default the JSON texts are compared using the handler's Assert, see FileHandler.ValueEqual for structural comparison.
+func AssertValue(t T, v any) bool {
+	return Default().AssertValue(t, v)
+}
+
+func (h *FileHandler) AssertValue(t T, v any) bool {
+	t.Helper()
+	if h.MarshalValue != nil {
+		b, err := h.MarshalValue(v)
+		if err != nil {
+			NoError(t, err, "failed to marshal value")
+			return false
+		}
+		return h.Assert(t, string(b))
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		NoError(t, err, "failed to marshal value")
+		return false
+	}
+	data := string(b) + "\n"
+	if h.ValueEqual == nil || v == nil {
+		return h.Assert(t, data)
+	}
+
+	// The values are compared instead of the texts, so Equal isn't replaced by MaxDiffLines or the content types.
+	c := *h
+	c.MaxDiffLines, c.ContentTypes = 0, nil
+	c.Equal = func(t T, expectedData, actualData string, msgAndArgs ...interface{}) bool {
+		t.Helper()
+		// Both sides are decoded from JSON so that fields which aren't serialized don't cause differences.
+		typ := reflect.TypeOf(v)
+		expected, actual := reflect.New(typ), reflect.New(typ)
+		NoError(t, json.Unmarshal([]byte(expectedData), expected.Interface()), "failed to decode golden file "+h.FileName(t))
+		NoError(t, json.Unmarshal([]byte(actualData), actual.Interface()), "failed to decode value")
+		return h.ValueEqual(t, expected.Elem().Interface(), actual.Elem().Interface(), msgAndArgs...)
+	}
+	return c.Assert(t, data)
+}
+
+// EqualWithCmp returns ValueEqual function which compares the values using cmp.Diff with the given options,
+// e.g. cmpopts.EquateApprox, cmpopts.IgnoreFields or cmpopts.SortSlices.
+// The diff is reported in the cmp format: lines prefixed with "-" are from the golden file and "+" from the actual value.
+func EqualWithCmp(opts ...cmp.Option) func(t T, expected, actual any, msgAndArgs ...interface{}) bool {
+	return func(t T, expected, actual any, msgAndArgs ...interface{}) bool {
+		t.Helper()
+		diff := cmp.Diff(expected, actual, opts...)
+		if diff == "" {
+			return true
+		}
+		msg := "value differs from golden file (-golden +actual):\n" + diff
+		if len(msgAndArgs) > 0 {
+			msg += "\n" + messageFromMsgAndArgs(msgAndArgs...)
+		}
+		t.Errorf("%s", msg)
+		return false
+	}
+}
+
+var spewConfig = spew.ConfigState{
+	Indent:                  "  ",
+	SortKeys:                true,
+	SpewKeys:                true,
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+	DisableMethods:          true,
+}
+
+// SpewValue renders the value as deterministic Go pretty-print using go-spew, for FileHandler.MarshalValue.
+// It's readable also for the types which don't marshal cleanly to JSON, e.g. with unexported fields, cyclic pointers or maps with struct keys.
+// Map keys are sorted and pointer addresses are omitted, so the output is stable between the runs.
+func SpewValue(v any) ([]byte, error) {
+	return []byte(spewConfig.Sdump(v)), nil
+}
@@ -0,0 +1,73 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+)
+
+type measurement struct {
+	Name  string
+	Value float64
+	Tags  []string
+	ID    string
+}
+
+func TestAssertValue(t *testing.T) {
+	golden.AssertValue(t, measurement{Name: "temperature", Value: 21.5, Tags: []string{"b", "a"}, ID: "1"})
+}
+
+func TestAssertValue_Cmp(t *testing.T) {
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll("./testdata/TestValueCmp"), "failed to remove testdata") })
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return true },
+		Equal:          golden.EqualWithDiff,
+		ValueEqual: golden.EqualWithCmp(
+			cmpopts.EquateApprox(0, 0.01),
+			cmpopts.SortSlices(func(a, b string) bool { return a < b }),
+			cmpopts.IgnoreFields(measurement{}, "ID"),
+		),
+	}
+
+	mt := &mockT{name: "TestValueCmp"}
+	assert.True(t, fh.AssertValue(mt, measurement{Name: "temperature", Value: 21.5, Tags: []string{"a", "b"}, ID: "1"}))
+
+	fh.ShouldRecreate = func(golden.T) bool { return false }
+	mt = &mockT{name: "TestValueCmp"}
+	assert.True(t, fh.AssertValue(mt, measurement{Name: "temperature", Value: 21.505, Tags: []string{"b", "a"}, ID: "2"}))
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestValueCmp"}
+	assert.False(t, fh.AssertValue(mt, measurement{Name: "humidity", Value: 21.5, Tags: []string{"a", "b"}, ID: "1"}))
+	assert.Contains(t, mt.msg, "(-golden +actual)")
+	assert.Contains(t, mt.msg, `"humidity"`)
+
+	recorder := &resultRecorder{results: map[string]golden.Result{}}
+	fh.Recorders = []golden.Recorder{recorder}
+	fh.MaxDiffLines = 10
+	mt = &mockT{name: "TestValueCmp"}
+	assert.True(t, fh.AssertValue(mt, measurement{Name: "temperature", Value: 21.505, Tags: []string{"b", "a"}, ID: "2"}),
+		"values are compared with ValueEqual even when MaxDiffLines is set")
+	assert.True(t, recorder.results["TestValueCmp"].Matched)
+}
+
+type node struct {
+	name     string
+	children map[string]*node
+	parent   *node
+}
+
+func TestSpewValue(t *testing.T) {
+	root := &node{name: "root", children: map[string]*node{}}
+	for _, name := range []string{"b", "a"} {
+		root.children[name] = &node{name: name, parent: root}
+	}
+
+	fh := *golden.DefaultHandler
+	fh.MarshalValue = golden.SpewValue
+	fh.AssertValue(t, root)
+}
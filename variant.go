@@ -0,0 +1,56 @@
+package golden
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// AssertAny checks the data against the golden file and its variants and passes when any of them matches.
+// This is useful when the output legitimately differs, e.g. between Go or dependency versions during a migration window.
+// The variant golden files are named by inserting the variant before the file extension,
+// e.g. testdata/TestX/TestX.go1.24.golden for variant "go1.24". The missing variants are ignored.
+// When none of the variants matches, the data is asserted against the golden file like with Assert,
+// so the failure shows the diff against the golden file and recreating rewrites the golden file, not the variants.
+func AssertAny(t T, data string, variants ...string) bool {
+	return Default().AssertAny(t, data, variants...)
+}
+
+func (h *FileHandler) AssertAny(t T, data string, variants ...string) bool {
+	t.Helper()
+	fileName := h.FileName(t)
+	for _, variant := range variants {
+		name := VariantFileName(fileName, variant)
+		c := *h
+		c.FileName = func(T) string { return name }
+		c.ShouldRecreate = func(T) bool { return false }
+		c.MissingFile = MissingFileFail
+		c.Tracker, c.Failures, c.Publisher, c.DiffCommand, c.Recorders, c.WriteDiff = nil, nil, nil, nil, nil, false
+
+		st := &silentT{T: t}
+		if c.AssertResult(st, data).Matched && !st.failed {
+			t.Logf("golden file variant %s matched", name)
+			return true
+		}
+	}
+
+	if len(variants) > 0 {
+		t.Logf("none of the golden file variants %s matched", strings.Join(variants, ", "))
+	}
+	return h.Assert(t, data)
+}
+
+// VariantFileName returns the name of the golden file variant, see AssertAny.
+func VariantFileName(fileName, variant string) string {
+	ext := filepath.Ext(fileName)
+	return strings.TrimSuffix(fileName, ext) + "." + variant + ext
+}
+
+// silentT discards the failures and logs, it's used for probing the candidates without failing the test.
+type silentT struct {
+	T
+	failed bool
+}
+
+func (s *silentT) Logf(string, ...any)           {}
+func (s *silentT) Errorf(string, ...interface{}) { s.failed = true }
+func (s *silentT) FailNow()                      { s.failed = true }
@@ -0,0 +1,46 @@
+package golden_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertAny(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("testdata/TestAny", 0o755))
+	require.NoError(t, os.WriteFile("testdata/TestAny/TestAny.golden", []byte("new output"), 0o600))
+	require.NoError(t, os.WriteFile("testdata/TestAny/TestAny.go1.23.golden", []byte("old output"), 0o600))
+
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: func(golden.T) bool { return false },
+		Equal:          golden.EqualWithDiff,
+	}
+
+	mt := &mockT{name: "TestAny"}
+	assert.True(t, fh.AssertAny(mt, "old output", "go1.22", "go1.23"))
+	assert.False(t, mt.failed)
+	assert.Contains(t, mt.logs, "golden file variant testdata/TestAny/TestAny.go1.23.golden matched")
+
+	mt = &mockT{name: "TestAny"}
+	assert.True(t, fh.AssertAny(mt, "new output", "go1.23"))
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestAny"}
+	assert.False(t, fh.AssertAny(mt, "other output", "go1.22", "go1.23"))
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.logs, "none of the golden file variants go1.22, go1.23 matched")
+	assert.Contains(t, mt.msg, "-new output")
+
+	fh.ShouldRecreate = func(golden.T) bool { return true }
+	assert.True(t, fh.AssertAny(&mockT{name: "TestAny"}, "old output", "go1.23"))
+	b, err := os.ReadFile("testdata/TestAny/TestAny.golden")
+	require.NoError(t, err)
+	assert.Equal(t, "new output", string(b), "matching variant isn't recreated")
+
+	assert.Equal(t, "testdata/x.v2.golden", golden.VariantFileName("testdata/x.golden", "v2"))
+}
@@ -0,0 +1,114 @@
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// envVerifyOutputDir is set by VerifyClean for the test binary regenerating the golden files,
+// the recreated golden files are written into it instead of the source tree.
+const envVerifyOutputDir = "GOLDEN_VERIFY_OUTPUT_DIR"
+
+// VerifyClean checks that the golden files in the dir are up to date. It runs the tests of the package again with
+// GOLDEN_FILES_RECREATE=true, the recreated golden files are written into a temporary directory instead of the source tree,
+// and fails when any of them differs from the committed golden file or isn't committed at all.
+// A single test proves the golden files are fresh without a git diff step in CI:
+//
+//	func TestGoldenFilesUpToDate(t *testing.T) {
+//		golden.VerifyClean(t, "testdata")
+//	}
+//
+// Only the assertions of handlers recreating with ParseRecreateFromEnv are regenerated. The inline snapshots
+// aren't rewritten when regenerating, the stale ones fail the regenerating run instead, see AssertInline.
+// The golden files which no test recreates aren't reported, use Tracker to find them.
+// VerifyClean is skipped inside the test binary it runs.
+func VerifyClean(t T, dir string) {
+	Default().VerifyClean(t, dir)
+}
+
+func (h *FileHandler) VerifyClean(t T, dir string) {
+	t.Helper()
+	if os.Getenv(envVerifyOutputDir) != "" {
+		if s, ok := t.(interface{ Skipf(string, ...any) }); ok {
+			s.Skipf("regenerating golden files for VerifyClean")
+		}
+		return
+	}
+
+	out, err := os.MkdirTemp("", "golden-verify")
+	NoError(t, err, "failed to create directory for regenerated golden files")
+	defer os.RemoveAll(out)
+	args := []string{"-test.count=1"}
+	if f := flag.Lookup("test.short"); f != nil && f.Value.String() == "true" {
+		args = append(args, "-test.short")
+	}
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "GOLDEN_FILES_RECREATE=true", envVerifyOutputDir+"="+out)
+	if b, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("failed to regenerate golden files: %v\n%s", err, b)
+		t.FailNow()
+		return
+	}
+
+	abs, err := filepath.Abs(dir)
+	NoError(t, err, "failed to resolve golden file directory")
+	regenerated := verifyMirrorPath(out, abs)
+	var stale []string
+	err = filepath.WalkDir(regenerated, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(regenerated, path)
+		if err != nil {
+			return err
+		}
+		fileName := filepath.Join(dir, rel)
+		actual, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		expected, err := h.readFile(filepath.Join(abs, rel), false)
+		switch {
+		case err != nil:
+			t.Errorf("golden file %s isn't committed: %v", fileName, err)
+			stale = append(stale, fileName)
+		case !bytes.Equal(expected, actual):
+			t.Errorf("golden file %s is out of date:\n%s", fileName, UnifiedDiff(fileName, "regenerated", string(expected), string(actual)))
+			stale = append(stale, fileName)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		NoError(t, err, "failed to compare regenerated golden files")
+		return
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		t.Errorf("%d golden files aren't up to date: %s\nupdate them with: GOLDEN_FILES_RECREATE=true go test ./...",
+			len(stale), strings.Join(stale, ", "))
+	}
+}
+
+// verifyOutputPath resolves the location for writing the recreated golden file when regenerating for VerifyClean.
+func verifyOutputPath(fileName string) (string, bool) {
+	outDir := os.Getenv(envVerifyOutputDir)
+	if outDir == "" {
+		return "", false
+	}
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return "", false
+	}
+	return verifyMirrorPath(outDir, abs), true
+}
+
+// verifyMirrorPath returns the location of the absolute path inside the output directory.
+func verifyMirrorPath(outDir, abs string) string {
+	return filepath.Join(outDir, strings.TrimPrefix(abs, filepath.VolumeName(abs)))
+}
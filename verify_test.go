@@ -0,0 +1,75 @@
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyHelperEnv makes the test binary assert the listed name=data pairs instead of running the tests,
+// it stands in for the package tests which VerifyClean runs to regenerate the golden files.
+const verifyHelperEnv = "GOLDEN_VERIFY_HELPER"
+
+func TestMain(m *testing.M) {
+	if assertions, ok := os.LookupEnv(verifyHelperEnv); ok {
+		for _, a := range strings.Split(assertions, ",") {
+			name, data, _ := strings.Cut(a, "=")
+			golden.Assert(&mockT{name: "TestVerify/" + name}, data)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestVerifyClean(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestVerify/fresh.golden", []byte("fresh")))
+	require.NoError(t, golden.OSStorage{}.WriteFile("testdata/TestVerify/changed.golden", []byte("old")))
+
+	t.Setenv(verifyHelperEnv, "fresh=fresh,changed=old")
+	mt := &mockT{name: "TestGoldenFilesUpToDate"}
+	golden.VerifyClean(mt, "testdata")
+	assert.False(t, mt.failed, mt.msg)
+
+	t.Setenv(verifyHelperEnv, "fresh=fresh,changed=new,added=added")
+	mt = &mockT{name: "TestGoldenFilesUpToDate"}
+	golden.VerifyClean(mt, "testdata")
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "golden file testdata/TestVerify/changed.golden is out of date")
+	assert.Contains(t, mt.msg, "2 golden files aren't up to date: testdata/TestVerify/added.golden, testdata/TestVerify/changed.golden")
+	assertFileContent(t, "testdata/TestVerify/changed.golden", "old")
+	assert.NoFileExists(t, "testdata/TestVerify/added.golden")
+}
+
+func TestVerifyCleanSkipped(t *testing.T) {
+	t.Setenv("GOLDEN_VERIFY_OUTPUT_DIR", t.TempDir())
+	mt := &mockT{name: "TestGoldenFilesUpToDate"}
+	golden.VerifyClean(mt, "testdata")
+	assert.False(t, mt.failed)
+}
+
+func TestVerifyClean_RegeneratedStorage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	out := t.TempDir()
+	t.Setenv("GOLDEN_VERIFY_OUTPUT_DIR", out)
+
+	storage := &memStorage{files: map[string]string{}}
+	fh := &golden.FileHandler{
+		FileName:       golden.TestNameToFilePath,
+		ShouldRecreate: golden.ParseRecreateFromEnv,
+		Equal:          golden.EqualWithDiff,
+		Storage:        storage,
+	}
+	t.Setenv("GOLDEN_FILES_RECREATE", "true")
+	assert.True(t, fh.Assert(&mockT{name: "TestVerify"}, "data"))
+
+	abs, err := filepath.Abs("testdata/TestVerify/TestVerify.golden")
+	require.NoError(t, err)
+	assertFileContent(t, filepath.Join(out, strings.TrimPrefix(abs, filepath.VolumeName(abs))), "data")
+	assert.Empty(t, storage.files, "regenerated golden files aren't written to the storage")
+}
@@ -0,0 +1,94 @@
+package golden
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// WebSocket message types as defined in RFC 6455, the same values are used by github.com/gorilla/websocket.
+const (
+	WebSocketText   = 1
+	WebSocketBinary = 2
+)
+
+// WebSocketConn is implemented by *websocket.Conn of github.com/gorilla/websocket.
+// Other libraries can be adapted by implementing ReadMessage, returning an error when the connection is closed.
+type WebSocketConn interface {
+	ReadMessage() (messageType int, p []byte, err error)
+}
+
+// AssertWebSocket reads n messages from the connection, or until the connection is closed when n <= 0,
+// and checks the golden file content against the transcript rendered by WebSocketTranscript.
+// Use read deadlines on the connection for bounding the time spent waiting for the messages:
+//
+//	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+//	require.NoError(t, err)
+//	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+//	golden.AssertWebSocket(t, conn, 3)
+func AssertWebSocket(t T, conn WebSocketConn, n int) bool {
+	return Default().AssertWebSocket(t, conn, n)
+}
+
+func (h *FileHandler) AssertWebSocket(t T, conn WebSocketConn, n int) bool {
+	t.Helper()
+	return h.Assert(t, WebSocketTranscript(conn, n))
+}
+
+// WebSocketTranscript reads n messages from the connection, or until the connection is closed when n <= 0,
+// and renders them in the received order. Each message is preceded by a header line with its sequence number,
+// type and size. Text messages are written as is and binary messages as hex dump.
+// The error ending the transcript before n messages is included as the last line with only its close code,
+// or as timeout when the read deadline expired, since the error messages contain the ephemeral network addresses.
+func WebSocketTranscript(conn WebSocketConn, n int) string {
+	sb := &strings.Builder{}
+	for i := 1; n <= 0 || i <= n; i++ {
+		typ, msg, err := conn.ReadMessage()
+		if err != nil {
+			sb.WriteString(webSocketClosed(err))
+			break
+		}
+
+		switch typ {
+		case WebSocketText:
+			fmt.Fprintf(sb, "--- message %d: text, %d bytes\n", i, len(msg))
+			sb.Write(msg)
+			if len(msg) > 0 && msg[len(msg)-1] != '\n' {
+				sb.WriteString("\n")
+			}
+		default:
+			fmt.Fprintf(sb, "--- message %d: binary, %d bytes\n", i, len(msg))
+			sb.WriteString(hex.Dump(msg))
+		}
+	}
+	return sb.String()
+}
+
+func webSocketClosed(err error) string {
+	var netErr interface{ Timeout() bool }
+	if errors.Is(err, os.ErrDeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		return "--- closed: timeout\n"
+	}
+	if code, ok := webSocketCloseCode(err); ok {
+		return fmt.Sprintf("--- closed: %d\n", code)
+	}
+	return "--- closed\n"
+}
+
+// webSocketCloseCode finds the close code from the error chain, e.g. *websocket.CloseError of github.com/gorilla/websocket,
+// without depending on the library by looking up an integer Code field.
+func webSocketCloseCode(err error) (int, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.Indirect(reflect.ValueOf(err))
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		if f := v.FieldByName("Code"); f.IsValid() && f.CanInt() {
+			return int(f.Int()), true
+		}
+	}
+	return 0, false
+}
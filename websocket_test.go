@@ -0,0 +1,64 @@
+package golden_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeWebSocket struct {
+	messages []string
+	binary   map[int]bool
+	err      error
+}
+
+type fakeCloseError struct {
+	Code int
+	Text string
+}
+
+func (e *fakeCloseError) Error() string {
+	return fmt.Sprintf("websocket: close %d: %s", e.Code, e.Text)
+}
+
+func (c *fakeWebSocket) ReadMessage() (int, []byte, error) {
+	if len(c.messages) == 0 {
+		if c.err != nil {
+			return 0, nil, c.err
+		}
+		return 0, nil, &fakeCloseError{Code: 1000, Text: "bye"}
+	}
+	typ := golden.WebSocketText
+	if c.binary[len(c.messages)] {
+		typ = golden.WebSocketBinary
+	}
+	msg := c.messages[0]
+	c.messages = c.messages[1:]
+	return typ, []byte(msg), nil
+}
+
+func TestAssertWebSocket(t *testing.T) {
+	conn := &fakeWebSocket{
+		messages: []string{`{"event":"joined"}`, "\x00\x01\x02ping", "multi\nline\n"},
+		binary:   map[int]bool{2: true},
+	}
+	golden.AssertWebSocket(t, conn, 0)
+}
+
+func TestWebSocketTranscript(t *testing.T) {
+	conn := &fakeWebSocket{messages: []string{"a", "b", "c"}}
+	assert.Equal(t, "--- message 1: text, 1 bytes\na\n--- message 2: text, 1 bytes\nb\n", golden.WebSocketTranscript(conn, 2))
+	assert.Equal(t, "--- message 1: text, 1 bytes\nc\n--- closed: 1000\n", golden.WebSocketTranscript(conn, 2))
+}
+
+func TestWebSocketTranscript_Closed(t *testing.T) {
+	timeout := fmt.Errorf("read tcp 127.0.0.1:51234->127.0.0.1:8080: %w", os.ErrDeadlineExceeded)
+	assert.Equal(t, "--- closed: timeout\n", golden.WebSocketTranscript(&fakeWebSocket{err: timeout}, 0))
+	wrapped := fmt.Errorf("read: %w", &fakeCloseError{Code: 1006, Text: "127.0.0.1:51234"})
+	assert.Equal(t, "--- closed: 1006\n", golden.WebSocketTranscript(&fakeWebSocket{err: wrapped}, 0))
+	assert.Equal(t, "--- closed\n", golden.WebSocketTranscript(&fakeWebSocket{err: errors.New("read tcp 127.0.0.1:51234: reset")}, 0))
+}
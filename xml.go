@@ -0,0 +1,133 @@
+package golden
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// PrettyXML formats the XML string with two space indentation, one element per line.
+// Whitespace between the elements is dropped, so the formatting of the original content doesn't affect the golden file.
+// Elements containing only text are kept on a single line and namespace prefixes are kept as written.
+func PrettyXML(t T, data string) string {
+	tokens, err := readXMLTokens(data)
+	if err != nil {
+		NoError(t, err, "failed to parse XML")
+		return data
+	}
+	return writeXMLTokens(tokens)
+}
+
+// readXMLTokens returns the tokens of the well-formed XML without the whitespace between the elements.
+func readXMLTokens(data string) ([]xml.Token, error) {
+	if err := validateXML(data); err != nil {
+		return nil, err
+	}
+
+	var tokens []xml.Token
+	dec := xml.NewDecoder(strings.NewReader(data))
+	for {
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			return tokens, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if cd, ok := tok.(xml.CharData); ok && len(strings.TrimSpace(string(cd))) == 0 {
+			continue
+		}
+		tokens = append(tokens, xml.CopyToken(tok))
+	}
+}
+
+// writeXMLTokens renders the tokens with two space indentation, one element per line.
+func writeXMLTokens(tokens []xml.Token) string {
+	sb := &strings.Builder{}
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		switch tok := tokens[i].(type) {
+		case xml.StartElement:
+			writeXMLIndent(sb, depth)
+			writeXMLStart(sb, tok)
+			if i+1 < len(tokens) {
+				if _, ok := tokens[i+1].(xml.EndElement); ok {
+					sb.WriteString("</" + xmlName(tok.Name) + ">\n")
+					i++
+					continue
+				}
+			}
+			if i+2 < len(tokens) {
+				cd, isText := tokens[i+1].(xml.CharData)
+				_, isEnd := tokens[i+2].(xml.EndElement)
+				if isText && isEnd {
+					writeXMLText(sb, cd)
+					sb.WriteString("</" + xmlName(tok.Name) + ">\n")
+					i += 2
+					continue
+				}
+			}
+			sb.WriteString("\n")
+			depth++
+		case xml.EndElement:
+			depth--
+			writeXMLIndent(sb, depth)
+			sb.WriteString("</" + xmlName(tok.Name) + ">\n")
+		case xml.CharData:
+			writeXMLIndent(sb, depth)
+			writeXMLText(sb, tok)
+			sb.WriteString("\n")
+		case xml.Comment:
+			writeXMLIndent(sb, depth)
+			sb.WriteString("<!--" + string(tok) + "-->\n")
+		case xml.ProcInst:
+			writeXMLIndent(sb, depth)
+			sb.WriteString("<?" + tok.Target + " " + string(tok.Inst) + "?>\n")
+		case xml.Directive:
+			writeXMLIndent(sb, depth)
+			sb.WriteString("<!" + string(tok) + ">\n")
+		}
+	}
+	return sb.String()
+}
+
+// validateXML checks that the XML is well-formed, RawToken used for formatting doesn't verify that the elements match.
+func validateXML(data string) error {
+	dec := xml.NewDecoder(strings.NewReader(data))
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func writeXMLIndent(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", max(depth, 0)))
+}
+
+func writeXMLStart(sb *strings.Builder, el xml.StartElement) {
+	sb.WriteString("<" + xmlName(el.Name))
+	for _, attr := range el.Attr {
+		sb.WriteString(" " + xmlName(attr.Name) + `="`)
+		_ = xml.EscapeText(sb, []byte(attr.Value))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(">")
+}
+
+func writeXMLText(sb *strings.Builder, cd xml.CharData) {
+	_ = xml.EscapeText(sb, []byte(strings.TrimSpace(string(cd))))
+}
+
+func xmlName(n xml.Name) string {
+	if n.Space != "" {
+		return n.Space + ":" + n.Local
+	}
+	return n.Local
+}
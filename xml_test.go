@@ -0,0 +1,29 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrettyXML(t *testing.T) {
+	mt := &mockT{name: "TestPrettyXML"}
+	got := golden.PrettyXML(mt, `<?xml version="1.0"?>
+<users>   <user id="1"><name>someone</name></user>
+<!-- comment --></users>`)
+	assert.Equal(t, `<?xml version="1.0"?>
+<users>
+  <user id="1">
+    <name>someone</name>
+  </user>
+  <!-- comment -->
+</users>
+`, got)
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestPrettyXML"}
+	golden.PrettyXML(mt, "<a>")
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "failed to parse XML")
+}
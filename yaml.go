@@ -0,0 +1,32 @@
+package golden
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PrettyYAML formats the YAML string with two space indentation while keeping the key order and comments.
+// Multi-document streams are supported.
+func PrettyYAML(t T, data string) string {
+	sb := &strings.Builder{}
+	dec := yaml.NewDecoder(strings.NewReader(data))
+	enc := yaml.NewEncoder(sb)
+	enc.SetIndent(2)
+	for {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		NoError(t, err, "failed to parse YAML")
+		if err != nil {
+			return data
+		}
+		NoError(t, enc.Encode(&node), "failed to format YAML")
+	}
+	NoError(t, enc.Close(), "failed to format YAML")
+	return sb.String()
+}
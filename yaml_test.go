@@ -0,0 +1,20 @@
+package golden_test
+
+import (
+	"testing"
+
+	"github.com/go-tstr/golden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrettyYAML(t *testing.T) {
+	mt := &mockT{name: "TestPrettyYAML"}
+	got := golden.PrettyYAML(mt, "b:    1 # comment\na:\n    - x\n    - y\n---\nc: 3\n")
+	assert.Equal(t, "b: 1 # comment\na:\n  - x\n  - y\n---\nc: 3\n", got)
+	assert.False(t, mt.failed)
+
+	mt = &mockT{name: "TestPrettyYAML"}
+	golden.PrettyYAML(mt, "a: [")
+	assert.True(t, mt.failed)
+	assert.Contains(t, mt.msg, "failed to parse YAML")
+}